@@ -2,9 +2,10 @@ package api
 
 import (
 	"errors"
-	"fmt"
 	"main/database/db"
+	"main/pagination"
 	"main/token"
+	"main/util"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -66,7 +67,13 @@ func (s *Server) getAcount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.ReadAnyAccountPermission)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !allowed {
 		err := errors.New("account doesn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -75,8 +82,13 @@ func (s *Server) getAcount(ctx *gin.Context) {
 }
 
 type listAccountRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageSize  int32  `form:"page_size" binding:"min=0"`
+	PageToken string `form:"page_token"`
+}
+
+type listAccountResponse struct {
+	Accounts      []*db.Account `json:"accounts"`
+	NextPageToken string        `json:"next_page_token"`
 }
 
 func (s *Server) listAcount(ctx *gin.Context) {
@@ -87,10 +99,22 @@ func (s *Server) listAcount(ctx *gin.Context) {
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// the page token is only valid for the owner that issued it
+	cursor, err := s.pageTokenMaker.VerifyToken(req.PageToken, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pageSize := pagination.PageSize(req.PageSize)
+
+	// fetch one extra row to find out if there is a next page
 	arg := &db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Owner:           authPayload.Username,
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorID:        cursor.ID,
+		PageLimit:       pageSize + 1,
 	}
 
 	accounts, err := s.store.ListAccounts(ctx, arg)
@@ -99,37 +123,38 @@ func (s *Server) listAcount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
-}
+	rsp := listAccountResponse{}
 
-type updateAccountURI struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
+	if len(accounts) > int(pageSize) {
+		accounts = accounts[:pageSize]
+		last := accounts[len(accounts)-1]
+
+		rsp.NextPageToken, err = s.pageTokenMaker.CreateToken(&pagination.Cursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}, authPayload.Username)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
 
-type updateAccountJSON struct {
-	Balance int64 `json:"balance" binding:"required"`
+	rsp.Accounts = accounts
+	ctx.JSON(http.StatusOK, rsp)
 }
 
-func (s *Server) updateAccount(ctx *gin.Context) {
-	var uri updateAccountURI
-	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
+type deleteAccountRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
 
-	var req updateAccountJSON
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+func (s *Server) deleteAccount(ctx *gin.Context) {
+	var req deleteAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	fmt.Println(req, "***")
 
-	arg := &db.UpdateAccountParams{
-		ID:      uri.ID,
-		Balance: req.Balance,
-	}
-
-	account, err := s.store.UpdateAccount(ctx, arg)
+	account, err := s.store.GetAccount(ctx, req.ID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -140,24 +165,23 @@ func (s *Server) updateAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
-}
-
-type deleteAccountRequest struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
-}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.DeleteAnyAccountPermission)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
 
-func (s *Server) deleteAccount(ctx *gin.Context) {
-	var req deleteAccountRequest
-	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	if !allowed {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
-	err := s.store.DeleteAccount(ctx, req.ID)
+	err = s.store.DeleteAccount(ctx, account.ID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		if db.ErrorCode(err) == db.ForeingKeyViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
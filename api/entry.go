@@ -0,0 +1,130 @@
+package api
+
+import (
+	"errors"
+	"main/database/db"
+	"main/pagination"
+	"main/token"
+	"main/util"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+type listAccountEntriesURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listAccountEntriesQuery struct {
+	FromTime  time.Time `form:"from_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	ToTime    time.Time `form:"to_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	Direction string    `form:"direction" binding:"omitempty,direction"`
+	PageSize  int32     `form:"page_size" binding:"min=0"`
+	PageToken string    `form:"page_token"`
+}
+
+type listAccountEntriesResponse struct {
+	Entries       []*db.ListAccountEntriesRow `json:"entries"`
+	NextPageToken string                      `json:"next_page_token"`
+}
+
+func (s *Server) listAccountEntries(ctx *gin.Context) {
+	var uri listAccountEntriesURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listAccountEntriesQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// the page token is only valid for the account and filters that issued it,
+	// taken before to_time defaults to now
+	requestParams := []any{uri.ID, req.FromTime, req.ToTime, req.Direction}
+
+	if req.ToTime.IsZero() {
+		req.ToTime = time.Now()
+	}
+
+	if !req.FromTime.Before(req.ToTime) {
+		err := errors.New("to_time must be after from_time")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := s.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.ReadAnyAccountPermission)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !allowed {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	cursor, err := s.pageTokenMaker.VerifyToken(req.PageToken, requestParams...)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pageSize := pagination.PageSize(req.PageSize)
+
+	// fetch one extra row to find out if there is a next page
+	arg := &db.ListAccountEntriesParams{
+		AccountID:       account.ID,
+		FromTime:        req.FromTime,
+		ToTime:          req.ToTime,
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorID:        cursor.ID,
+		PageLimit:       pageSize + 1,
+	}
+
+	if req.Direction != "" {
+		arg.Direction = &req.Direction
+	}
+
+	entries, err := s.store.ListAccountEntries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := listAccountEntriesResponse{}
+
+	if len(entries) > int(pageSize) {
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+
+		rsp.NextPageToken, err = s.pageTokenMaker.CreateToken(&pagination.Cursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}, requestParams...)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	rsp.Entries = entries
+	ctx.JSON(http.StatusOK, rsp)
+}
@@ -1,7 +1,11 @@
 package api
 
 import (
+	"context"
 	"main/database/db"
+	"main/lockout"
+	"main/permission"
+	"main/revocation"
 	"main/util"
 	"os"
 	"testing"
@@ -16,6 +20,9 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// testLoginPolicy locks a user out after a few failed logins
+var testLoginPolicy = lockout.Policy{LockoutAfter: 3, LockoutDuration: time.Minute}
+
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := &util.ConfigDatabase{
 		SecretKey:     util.RandomString(32),
@@ -25,5 +32,39 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	server, err := NewServer(store, config)
 	require.NoError(t, err)
 
+	// keep the password change lookups out of the store expectations of every test
+	server.revocationChecker = revocation.NewChecker(unchangedPasswordUsers{}, time.Minute)
+	server.permissionChecker = permission.NewChecker(seededRolePermissions{}, time.Minute)
+	server.loginGuard = lockout.NewGuard(lockout.NewMemoryStore(), testLoginPolicy, lockout.Policy{})
+	require.NoError(t, server.setupRouter())
+
 	return server
 }
+
+// unchangedPasswordUsers returns users that never changed their password
+type unchangedPasswordUsers struct{}
+
+func (unchangedPasswordUsers) GetUser(ctx context.Context, username string) (*db.User, error) {
+	return &db.User{Username: username}, nil
+}
+
+// seededRolePermissions grants the permissions the migrations seed the roles with
+type seededRolePermissions struct{}
+
+func (seededRolePermissions) ListRolePermissions(ctx context.Context, role string) ([]*db.RolePermission, error) {
+	seeded := map[string][]string{
+		util.DepositorRole: {util.CreateAccountPermission, util.CreateTransferPermission},
+		util.BankerRole: {
+			util.CreateAccountPermission, util.ReadAnyAccountPermission, util.DeleteAnyAccountPermission,
+			util.CreateTransferPermission, util.ManageUsersPermission, util.ManageRolesPermission,
+		},
+		util.SupportRole: {util.ReadAnyAccountPermission},
+	}
+
+	rolePermissions := []*db.RolePermission{}
+	for _, permission := range seeded[role] {
+		rolePermissions = append(rolePermissions, &db.RolePermission{Role: role, Permission: permission})
+	}
+
+	return rolePermissions, nil
+}
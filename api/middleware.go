@@ -3,6 +3,8 @@ package api
 import (
 	"errors"
 	"fmt"
+	"main/permission"
+	"main/revocation"
 	"main/token"
 	"net/http"
 	"strings"
@@ -16,7 +18,7 @@ const (
 	authorizationPayloadKey = "auth_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, revocationChecker *revocation.Checker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -46,7 +48,35 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		err = revocationChecker.Check(ctx, payload)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// requirePermission aborts requests whose role was not granted the permission.
+// It must run after authMiddleware
+func requirePermission(permissionChecker *permission.Checker, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		allowed, err := permissionChecker.HasPermission(ctx, authPayload.Role, permission)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !allowed {
+			err := fmt.Errorf("role %s is not granted %s", authPayload.Role, permission)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
+		ctx.Next()
+	}
+}
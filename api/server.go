@@ -3,6 +3,10 @@ package api
 import (
 	"fmt"
 	"main/database/db"
+	"main/lockout"
+	"main/pagination"
+	"main/permission"
+	"main/revocation"
 	"main/token"
 	"main/util"
 
@@ -16,49 +20,87 @@ type Server struct {
 	config     *util.ConfigDatabase
 	store      db.Store
 	tokenMaker token.Maker
-	router     *gin.Engine
+	// refreshTokenMaker signs refresh tokens, so they never pass as access tokens
+	refreshTokenMaker token.Maker
+	pageTokenMaker    *pagination.PageTokenMaker
+	// revocationChecker rejects tokens issued before the last password change
+	revocationChecker *revocation.Checker
+	// permissionChecker resolves the permissions granted to the role of a token
+	permissionChecker *permission.Checker
+	router            *gin.Engine
+	// loginGuard slows down and locks out password guessing
+	loginGuard *lockout.Guard
 }
 
 // NewServer creates a new HTTP server and setup routing
 func NewServer(store db.Store, cfg *util.ConfigDatabase) (*Server, error) {
-	tokenMaker, err := token.NewPASETOMaker(cfg.SecretKey)
+	tokenMaker, err := token.NewMaker(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	// refresh tokens are signed with their own key so they can never pass as access tokens
+	refreshTokenMaker, err := token.NewPASETOMaker(util.DeriveKey(cfg.SecretKey, "refresh-token"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create refresh token maker: %w", err)
+	}
+
+	loginGuard, err := lockout.NewGuardFromConfig(cfg, store)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create login guard: %w", err)
+	}
+
 	server := &Server{
-		config:     cfg,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:            cfg,
+		store:             store,
+		tokenMaker:        tokenMaker,
+		refreshTokenMaker: refreshTokenMaker,
+		pageTokenMaker:    pagination.NewPageTokenMaker(util.DeriveKey(cfg.SecretKey, "page-token")),
+		revocationChecker: revocation.NewChecker(store, revocation.DefaultCacheTTL),
+		permissionChecker: permission.NewChecker(store, permission.DefaultCacheTTL),
+		loginGuard:        loginGuard,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("direction", validDirection)
+		v.RegisterValidation("statement_format", validStatementFormat)
+	}
+
+	if err := server.setupRouter(); err != nil {
+		return nil, err
 	}
 
-	server.setupRouter()
 	return server, nil
 }
 
-func (s *Server) setupRouter() {
+func (s *Server) setupRouter() error {
 	router := gin.Default()
 
+	// only trust X-Forwarded-For from the configured proxies, so clients cannot pick
+	// the ip their failed logins are counted against
+	if err := router.SetTrustedProxies(s.config.TrustedProxies); err != nil {
+		return fmt.Errorf("cannot set trusted proxies: %w", err)
+	}
+
 	router.POST("/users", s.createUser)
 	router.POST("/users/login", s.loginUser)
 	router.POST("/tokens/renew", s.renewAccessToken)
 
 	authRoutes := router.Group("/")
-	authRoutes.Use(authMiddleware(s.tokenMaker))
+	authRoutes.Use(authMiddleware(s.tokenMaker, s.revocationChecker))
 
-	authRoutes.POST("/accounts", s.createAccount)
+	authRoutes.POST("/accounts", requirePermission(s.permissionChecker, util.CreateAccountPermission), s.createAccount)
 	authRoutes.GET("/accounts/:id", s.getAcount)
 	authRoutes.GET("/accounts", s.listAcount)
-	authRoutes.PATCH("/accounts/:id", s.updateAccount)
 	authRoutes.DELETE("/accounts/:id", s.deleteAccount)
+	authRoutes.GET("/accounts/:id/entries", s.listAccountEntries)
+	authRoutes.GET("/accounts/:id/statement", s.exportStatement)
 
-	authRoutes.POST("/transfers", s.createTransfer)
+	authRoutes.POST("/transfers", requirePermission(s.permissionChecker, util.CreateTransferPermission), s.createTransfer)
 
 	s.router = router
+	return nil
 }
 
 // Start runs the HTTP server on a specific address
@@ -66,6 +108,16 @@ func (s *Server) Start(address string) error {
 	return s.router.Run(address)
 }
 
+// isOwnerOrPermitted returns true if the resource of owner belongs to the authenticated
+// user, or if their role was granted the permission to act on resources of any user
+func (s *Server) isOwnerOrPermitted(ctx *gin.Context, payload *token.Payload, owner string, permission string) (bool, error) {
+	if owner == payload.Username {
+		return true, nil
+	}
+
+	return s.permissionChecker.HasPermission(ctx, payload.Role, permission)
+}
+
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
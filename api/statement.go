@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"main/database/db"
+	"main/statement"
+	"main/token"
+	"main/util"
+	"main/validate"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+type exportStatementURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type exportStatementQuery struct {
+	FromTime time.Time `form:"from_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	ToTime   time.Time `form:"to_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	Format   string    `form:"format" binding:"required,statement_format"`
+}
+
+func (s *Server) exportStatement(ctx *gin.Context) {
+	var uri exportStatementURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req exportStatementQuery
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := validate.ValidateStatementPeriod(req.FromTime, req.ToTime); err != nil {
+		err = fmt.Errorf("to_time %w", err)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := s.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.ReadAnyAccountPermission)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !allowed {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	txResult, err := s.store.StatementTx(ctx, &db.StatementTxParams{
+		AccountID: account.ID,
+		FromTime:  req.FromTime,
+		ToTime:    req.ToTime,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	balances, entries := txResult.Balances, txResult.Entries
+
+	transfers := []*db.Transfer{}
+	if transferIDs := statement.TransferIDs(entries); len(transferIDs) > 0 {
+		transfers, err = s.store.ListTransfersByIDs(ctx, transferIDs)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	stmt := statement.New(account, req.FromTime, req.ToTime, balances, entries, transfers)
+
+	var buf bytes.Buffer
+	if err := stmt.Render(&buf, req.Format); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stmt.FileName(req.Format)))
+	ctx.Data(http.StatusOK, statement.ContentType(req.Format), buf.Bytes())
+}
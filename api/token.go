@@ -1,11 +1,14 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"main/database/db"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -14,8 +17,11 @@ type renewAccessTokenRequest struct {
 }
 
 type renewAccessTokenResponse struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	SessionID             uuid.UUID `json:"session_id"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
 func (s *Server) renewAccessToken(ctx *gin.Context) {
@@ -25,7 +31,13 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	refreshPayload, err := s.tokenMaker.VerifyToken(req.RefreshToken)
+	refreshPayload, err := s.refreshTokenMaker.VerifyToken(req.RefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	err = s.revocationChecker.Check(ctx, refreshPayload)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -66,16 +78,67 @@ func (s *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	if session.RotatedAt.Valid {
+		s.blockSessionFamily(ctx, session)
+		return
+	}
+
 	accessToken, accessPayload, err := s.tokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, s.config.TokenDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	// the rotated refresh token keeps the expiry of the family so renewing never extends a login
+	refreshToken, newRefreshPayload, err := s.refreshTokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, time.Until(session.ExpiresAt))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	txResult, err := s.store.RotateSessionTx(ctx, &db.RotateSessionTxParams{
+		SessionID: session.ID,
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    ctx.Request.UserAgent(),
+			ClientIp:     ctx.ClientIP(),
+			IsBlocked:    false,
+			ExpiresAt:    newRefreshPayload.ExpiredAt,
+			FamilyID:     session.FamilyID,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			s.blockSessionFamily(ctx, session)
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	response := renewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+		SessionID:             txResult.Session.ID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiredAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: newRefreshPayload.ExpiredAt,
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+// blockSessionFamily blocks every session descending from the same login
+// after an already rotated refresh token was presented again
+func (s *Server) blockSessionFamily(ctx *gin.Context, session *db.Session) {
+	_, err := s.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = fmt.Errorf("refresh token reuse detected")
+	ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"main/database/db"
+	"main/metrics"
 	"main/token"
 	"net/http"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+const idempotencyKeyHeader = "Idempotency-Key"
+
 type transferRequest struct {
 	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
 	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
@@ -42,18 +45,36 @@ func (s *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateTransferParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+	arg := db.TransferTxParams{
+		CreateTransferParams: db.CreateTransferParams{
+			FromAccountID: req.FromAccountID,
+			ToAccountID:   req.ToAccountID,
+			Amount:        req.Amount,
+		},
+		Username:       authPayload.Username,
+		IdempotencyKey: ctx.GetHeader(idempotencyKeyHeader),
 	}
 
 	result, err := s.store.TransferTx(ctx, &arg)
 	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	if !result.Replayed {
+		metrics.ObserveTransfer(req.Currency, req.Amount)
+	}
+
 	ctx.JSON(http.StatusOK, result)
 }
 
@@ -1,9 +1,13 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"main/database/db"
 	"main/util"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -91,9 +95,29 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	retryAfter, err := s.loginGuard.Attempt(ctx, req.Username, ctx.ClientIP())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if retryAfter > 0 {
+		seconds := int64(math.Ceil(retryAfter.Seconds()))
+		ctx.Header("Retry-After", strconv.FormatInt(seconds, 10))
+
+		err := fmt.Errorf("too many failed attempts, retry after %d seconds", seconds)
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(err))
+		return
+	}
+
 	user, err := s.store.GetUser(ctx, req.Username)
 	if err != nil {
 		if err == pgx.ErrNoRows {
+			if err := s.loginGuard.RecordFailure(ctx, ctx.ClientIP()); err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
@@ -104,17 +128,41 @@ func (s *Server) loginUser(ctx *gin.Context) {
 
 	err = util.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
+		if err := s.loginGuard.RecordFailure(ctx, ctx.ClientIP()); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 
+	if user.IsDisabled {
+		err := errors.New("user is disabled")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	// the two-step MFA login is only offered by the gRPC gateway
+	if user.IsTotpEnabled || user.Role != util.DepositorRole {
+		err := errors.New("two-factor authentication required, log in through /v1/login_user")
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	err = s.loginGuard.RecordSuccess(ctx, user.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.TokenDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.RefreshDuration)
+	refreshToken, refreshPayload, err := s.refreshTokenMaker.CreateToken(user.Username, user.Role, s.config.RefreshDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -128,6 +176,7 @@ func (s *Server) loginUser(ctx *gin.Context) {
 		ClientIp:     ctx.ClientIP(),
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     refreshPayload.ID,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
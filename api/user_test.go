@@ -7,11 +7,13 @@ import (
 	"io"
 	"main/database/db"
 	"main/database/mockdb"
+	"main/lockout"
 	"main/util"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5"
@@ -209,6 +211,7 @@ func randomUser(t *testing.T) (*db.User, string) {
 
 	user := &db.User{
 		Username:       util.RandomOwner(),
+		Role:           util.DepositorRole,
 		HashedPassword: hashedPassword,
 		FullName:       util.RandomOwner(),
 		Email:          util.RandomEmail(),
@@ -274,6 +277,40 @@ func TestLoginUserAPI(t *testing.T) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
 			},
 		},
+		{
+			name: "MFARequired",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				mfaUser := *user
+				mfaUser.IsTotpEnabled = true
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(&mfaUser, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "UserDisabled",
+			body: gin.H{
+				"username": user.Username,
+				"password": password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				disabledUser := *user
+				disabledUser.IsDisabled = true
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(&disabledUser, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			body: gin.H{
@@ -324,3 +361,68 @@ func TestLoginUserAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginUserAPILockout(t *testing.T) {
+	user, password := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(testLoginPolicy.LockoutAfter).Return(user, nil)
+	store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	login := func(password string) *httptest.ResponseRecorder {
+		data, err := json.Marshal(gin.H{"username": user.Username, "password": password})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewReader(data))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	for i := 0; i < testLoginPolicy.LockoutAfter; i++ {
+		require.Equal(t, http.StatusUnauthorized, login("incorrect").Code)
+	}
+
+	// even the correct password is rejected while the user is locked out
+	recorder := login(password)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "60", recorder.Header().Get("Retry-After"))
+}
+
+func TestLoginUserAPIIgnoresUntrustedForwardedFor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(2).Return(nil, pgx.ErrNoRows)
+
+	server := newTestServer(t, store)
+	server.loginGuard = lockout.NewGuard(lockout.NewMemoryStore(), lockout.Policy{}, lockout.Policy{LockoutAfter: 2, LockoutDuration: time.Minute})
+
+	login := func(forwardedFor string) *httptest.ResponseRecorder {
+		data, err := json.Marshal(gin.H{"username": util.RandomOwner(), "password": "incorrect"})
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewReader(data))
+		require.NoError(t, err)
+		request.RemoteAddr = "203.0.113.7:4321"
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusNotFound, login("198.51.100.1").Code)
+	require.Equal(t, http.StatusNotFound, login("198.51.100.2").Code)
+
+	// a new forwarded ip from an untrusted peer does not get a fresh bucket
+	require.Equal(t, http.StatusTooManyRequests, login("198.51.100.3").Code)
+}
@@ -13,3 +13,19 @@ var validCurrency validator.Func = func(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validDirection validator.Func = func(fl validator.FieldLevel) bool {
+	if direction, ok := fl.Field().Interface().(string); ok {
+		// check entry direction is supported
+		return util.IsSupportedDirection(direction)
+	}
+	return false
+}
+
+var validStatementFormat validator.Func = func(fl validator.FieldLevel) bool {
+	if format, ok := fl.Field().Interface().(string); ok {
+		// check statement export format is supported
+		return util.IsSupportedStatementFormat(format)
+	}
+	return false
+}
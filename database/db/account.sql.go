@@ -7,13 +7,14 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit
 `
 
 type AddAccountBalanceParams struct {
@@ -30,13 +31,14 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg *AddAccountBalanceP
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
 	)
 	return &i, err
 }
 
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3) RETURNING id, owner, balance, currency, created_at
+VALUES ($1, $2, $3) RETURNING id, owner, balance, currency, created_at, overdraft_limit
 `
 
 type CreateAccountParams struct {
@@ -54,6 +56,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg *CreateAccountParams) (
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
 	)
 	return &i, err
 }
@@ -69,7 +72,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit FROM accounts
 WHERE id = $1
 ORDER BY id
 LIMIT 1
@@ -84,12 +87,13 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (*Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
 	)
 	return &i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit FROM accounts
 WHERE id = $1
 ORDER BY id
 LIMIT 1
@@ -105,26 +109,74 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (*Account,
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
 	)
 	return &i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit FROM accounts
 WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3
+  AND (created_at, id) > ($2::timestamptz, $3::bigint)
+ORDER BY created_at, id
+LIMIT $4
 `
 
 type ListAccountsParams struct {
-	Owner  string `db:"owner" json:"owner"`
-	Limit  int32  `db:"limit" json:"limit"`
-	Offset int32  `db:"offset" json:"offset"`
+	Owner           string    `db:"owner" json:"owner"`
+	CursorCreatedAt time.Time `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorID        int64     `db:"cursor_id" json:"cursor_id"`
+	PageLimit       int32     `db:"page_limit" json:"page_limit"`
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, arg *ListAccountsParams) ([]*Account, error) {
-	rows, err := q.db.Query(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAccounts,
+		arg.Owner,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.OverdraftLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementAccounts = `-- name: ListStatementAccounts :many
+SELECT a.id, a.owner, a.balance, a.currency, a.created_at, a.overdraft_limit FROM accounts a
+JOIN users u ON u.username = a.owner
+WHERE NOT u.monthly_statement_opt_out
+  AND a.id > $1
+ORDER BY a.id
+LIMIT $2
+`
+
+type ListStatementAccountsParams struct {
+	CursorID  int64 `db:"cursor_id" json:"cursor_id"`
+	PageLimit int32 `db:"page_limit" json:"page_limit"`
+}
+
+func (q *Queries) ListStatementAccounts(ctx context.Context, arg *ListStatementAccountsParams) ([]*Account, error) {
+	rows, err := q.db.Query(ctx, listStatementAccounts, arg.CursorID, arg.PageLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +190,7 @@ func (q *Queries) ListAccounts(ctx context.Context, arg *ListAccountsParams) ([]
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.OverdraftLimit,
 		); err != nil {
 			return nil, err
 		}
@@ -153,7 +206,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit
 `
 
 type UpdateAccountParams struct {
@@ -170,6 +223,7 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg *UpdateAccountParams) (
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
 	)
 	return &i, err
 }
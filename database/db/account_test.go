@@ -89,9 +89,8 @@ func TestListAccounts(t *testing.T) {
 	}
 
 	arg := ListAccountsParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Owner:     lastAccount.Owner,
+		PageLimit: 5,
 	}
 
 	accounts, err := testStore.ListAccounts(context.Background(), &arg)
@@ -103,3 +102,29 @@ func TestListAccounts(t *testing.T) {
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
 }
+
+func TestListStatementAccounts(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	optOut := true
+	_, err := testStore.UpdateUser(context.Background(), &UpdateUserParams{
+		Username:               account2.Owner,
+		MonthlyStatementOptOut: &optOut,
+	})
+	require.NoError(t, err)
+
+	arg := ListStatementAccountsParams{
+		CursorID:  account1.ID - 1,
+		PageLimit: 100,
+	}
+
+	accounts, err := testStore.ListStatementAccounts(context.Background(), &arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, accounts)
+	require.Equal(t, account1.ID, accounts[0].ID)
+
+	for _, account := range accounts {
+		require.NotEqual(t, account2.ID, account.ID)
+	}
+}
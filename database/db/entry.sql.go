@@ -7,26 +7,29 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
-INSERT INTO entries (account_id, amount)
-VALUES ($1, $2) RETURNING id, account_id, amount, created_at
+INSERT INTO entries (account_id, amount, transfer_id)
+VALUES ($1, $2, $3) RETURNING id, account_id, amount, created_at, transfer_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `db:"account_id" json:"account_id"`
-	Amount    int64 `db:"amount" json:"amount"`
+	AccountID  int64  `db:"account_id" json:"account_id"`
+	Amount     int64  `db:"amount" json:"amount"`
+	TransferID *int64 `db:"transfer_id" json:"transfer_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg *CreateEntryParams) (*Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount, arg.TransferID)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return &i, err
 }
@@ -41,8 +44,36 @@ func (q *Queries) DeleteEntry(ctx context.Context, id int64) error {
 	return err
 }
 
+const getAccountStatementBalances = `-- name: GetAccountStatementBalances :one
+SELECT
+  (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= $1::timestamptz), 0))::bigint AS opening_balance,
+  (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= $2::timestamptz), 0))::bigint AS closing_balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+WHERE a.id = $3
+GROUP BY a.id
+`
+
+type GetAccountStatementBalancesParams struct {
+	FromTime  time.Time `db:"from_time" json:"from_time"`
+	ToTime    time.Time `db:"to_time" json:"to_time"`
+	AccountID int64     `db:"account_id" json:"account_id"`
+}
+
+type GetAccountStatementBalancesRow struct {
+	OpeningBalance int64 `db:"opening_balance" json:"opening_balance"`
+	ClosingBalance int64 `db:"closing_balance" json:"closing_balance"`
+}
+
+func (q *Queries) GetAccountStatementBalances(ctx context.Context, arg *GetAccountStatementBalancesParams) (*GetAccountStatementBalancesRow, error) {
+	row := q.db.QueryRow(ctx, getAccountStatementBalances, arg.FromTime, arg.ToTime, arg.AccountID)
+	var i GetAccountStatementBalancesRow
+	err := row.Scan(&i.OpeningBalance, &i.ClosingBalance)
+	return &i, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
 WHERE id = $1
 ORDER BY id
 LIMIT 1
@@ -56,26 +87,183 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (*Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return &i, err
 }
 
+const listAccountEntries = `-- name: ListAccountEntries :many
+WITH page AS (
+  SELECT id, account_id, amount, created_at, transfer_id FROM entries
+  WHERE account_id = $1
+    AND created_at >= $2
+    AND created_at < $3
+    AND (
+      $4::text IS NULL
+      OR ($4::text = 'debit' AND amount < 0)
+      OR ($4::text = 'credit' AND amount > 0)
+    )
+    AND (created_at, id) > ($5::timestamptz, $6::bigint)
+  ORDER BY created_at, id
+  LIMIT $7
+),
+-- every entry from the first to the last row of the page, including the filtered out ones
+span AS (
+  SELECT
+    e.id,
+    COALESCE(SUM(e.amount) OVER (
+      ORDER BY e.created_at DESC, e.id DESC
+      ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+    ), 0) AS newer_amount
+  FROM entries e
+  WHERE e.account_id = $1
+    AND (e.created_at, e.id) >= (SELECT created_at, id FROM page ORDER BY created_at, id LIMIT 1)
+    AND (e.created_at, e.id) <= (SELECT created_at, id FROM page ORDER BY created_at DESC, id DESC LIMIT 1)
+),
+-- the entries after the page, summed once through the (account_id, created_at, id) index
+after_page AS (
+  SELECT COALESCE(SUM(e.amount), 0) AS amount
+  FROM entries e
+  WHERE e.account_id = $1
+    AND (e.created_at, e.id) > (SELECT created_at, id FROM page ORDER BY created_at DESC, id DESC LIMIT 1)
+)
+SELECT
+  p.id,
+  p.account_id,
+  p.amount,
+  p.created_at,
+  p.transfer_id,
+  (a.balance - ap.amount - s.newer_amount)::bigint AS running_balance,
+  COALESCE(CASE
+    WHEN t.from_account_id = p.account_id THEN t.to_account_id
+    ELSE t.from_account_id
+  END, 0)::bigint AS counterparty_account_id
+FROM page p
+JOIN span s ON s.id = p.id
+JOIN accounts a ON a.id = p.account_id
+CROSS JOIN after_page ap
+LEFT JOIN transfers t ON t.id = p.transfer_id
+ORDER BY p.created_at, p.id;
+`
+
+type ListAccountEntriesParams struct {
+	AccountID       int64     `db:"account_id" json:"account_id"`
+	FromTime        time.Time `db:"from_time" json:"from_time"`
+	ToTime          time.Time `db:"to_time" json:"to_time"`
+	Direction       *string   `db:"direction" json:"direction"`
+	CursorCreatedAt time.Time `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorID        int64     `db:"cursor_id" json:"cursor_id"`
+	PageLimit       int32     `db:"page_limit" json:"page_limit"`
+}
+
+type ListAccountEntriesRow struct {
+	ID                    int64     `db:"id" json:"id"`
+	AccountID             int64     `db:"account_id" json:"account_id"`
+	Amount                int64     `db:"amount" json:"amount"`
+	CreatedAt             time.Time `db:"created_at" json:"created_at"`
+	TransferID            *int64    `db:"transfer_id" json:"transfer_id"`
+	RunningBalance        int64     `db:"running_balance" json:"running_balance"`
+	CounterpartyAccountID int64     `db:"counterparty_account_id" json:"counterparty_account_id"`
+}
+
+func (q *Queries) ListAccountEntries(ctx context.Context, arg *ListAccountEntriesParams) ([]*ListAccountEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listAccountEntries,
+		arg.AccountID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Direction,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListAccountEntriesRow{}
+	for rows.Next() {
+		var i ListAccountEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.RunningBalance,
+			&i.CounterpartyAccountID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
 WHERE account_id = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3
+  AND (created_at, id) > ($2::timestamptz, $3::bigint)
+ORDER BY created_at, id
+LIMIT $4
 `
 
 type ListEntriesParams struct {
-	AccountID int64 `db:"account_id" json:"account_id"`
-	Limit     int32 `db:"limit" json:"limit"`
-	Offset    int32 `db:"offset" json:"offset"`
+	AccountID       int64     `db:"account_id" json:"account_id"`
+	CursorCreatedAt time.Time `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorID        int64     `db:"cursor_id" json:"cursor_id"`
+	PageLimit       int32     `db:"page_limit" json:"page_limit"`
 }
 
 func (q *Queries) ListEntries(ctx context.Context, arg *ListEntriesParams) ([]*Entry, error) {
-	rows, err := q.db.Query(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries,
+		arg.AccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
+WHERE account_id = $1
+  AND created_at >= $2
+  AND created_at < $3
+ORDER BY created_at, id
+`
+
+type ListStatementEntriesParams struct {
+	AccountID int64     `db:"account_id" json:"account_id"`
+	FromTime  time.Time `db:"from_time" json:"from_time"`
+	ToTime    time.Time `db:"to_time" json:"to_time"`
+}
+
+func (q *Queries) ListStatementEntries(ctx context.Context, arg *ListStatementEntriesParams) ([]*Entry, error) {
+	rows, err := q.db.Query(ctx, listStatementEntries, arg.AccountID, arg.FromTime, arg.ToTime)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +276,7 @@ func (q *Queries) ListEntries(ctx context.Context, arg *ListEntriesParams) ([]*E
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
 		); err != nil {
 			return nil, err
 		}
@@ -103,7 +292,7 @@ const updateEntry = `-- name: UpdateEntry :one
 UPDATE entries
 SET amount = $2
 WHERE id = $1
-RETURNING id, account_id, amount, created_at
+RETURNING id, account_id, amount, created_at, transfer_id
 `
 
 type UpdateEntryParams struct {
@@ -119,6 +308,7 @@ func (q *Queries) UpdateEntry(ctx context.Context, arg *UpdateEntryParams) (*Ent
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return &i, err
 }
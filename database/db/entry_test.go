@@ -88,10 +88,17 @@ func TestListEntries(t *testing.T) {
 
 	arg := ListEntriesParams{
 		AccountID: account.ID,
-		Limit:     5,
-		Offset:    5,
+		PageLimit: 5,
 	}
 
+	firstPage, err := testStore.ListEntries(context.Background(), &arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 5)
+
+	last := firstPage[len(firstPage)-1]
+	arg.CursorCreatedAt = last.CreatedAt
+	arg.CursorID = last.ID
+
 	entries, err := testStore.ListEntries(context.Background(), &arg)
 	require.NoError(t, err)
 	require.Len(t, entries, 5)
@@ -99,5 +106,171 @@ func TestListEntries(t *testing.T) {
 	for _, entry := range entries {
 		require.NotEmpty(t, entry)
 		require.Equal(t, arg.AccountID, entry.AccountID)
+		require.NotEqual(t, last.ID, entry.ID)
+	}
+}
+
+func TestListAccountEntries(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
+
+	amounts := []int64{10, 20, 30}
+	for _, amount := range amounts {
+		_, err := testStore.TransferTx(context.Background(), &TransferTxParams{
+			CreateTransferParams: CreateTransferParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	debit := util.DebitDirection
+	arg := ListAccountEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  time.Now().Add(-time.Minute),
+		ToTime:    time.Now().Add(time.Minute),
+		Direction: &debit,
+		PageLimit: 5,
+	}
+
+	entries, err := testStore.ListAccountEntries(context.Background(), &arg)
+	require.NoError(t, err)
+	require.Len(t, entries, len(amounts))
+
+	balance := account1.Balance
+	for i, entry := range entries {
+		balance -= amounts[i]
+		require.Equal(t, account1.ID, entry.AccountID)
+		require.Equal(t, -amounts[i], entry.Amount)
+		require.Equal(t, balance, entry.RunningBalance)
+		require.Equal(t, account2.ID, entry.CounterpartyAccountID)
+		require.NotNil(t, entry.TransferID)
+	}
+}
+
+func TestStatementEntriesAndBalances(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
+
+	fromTime := time.Now()
+
+	amounts := []int64{10, 20, 30}
+	for _, amount := range amounts {
+		_, err := testStore.TransferTx(context.Background(), &TransferTxParams{
+			CreateTransferParams: CreateTransferParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	toTime := time.Now().Add(time.Minute)
+
+	entries, err := testStore.ListStatementEntries(context.Background(), &ListStatementEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, len(amounts))
+
+	balances, err := testStore.GetAccountStatementBalances(context.Background(), &GetAccountStatementBalancesParams{
+		FromTime:  fromTime,
+		ToTime:    toTime,
+		AccountID: account1.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, balances.OpeningBalance)
+	require.Equal(t, account1.Balance-60, balances.ClosingBalance)
+
+	transferIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		require.NotNil(t, entry.TransferID)
+		transferIDs = append(transferIDs, *entry.TransferID)
 	}
+
+	transfers, err := testStore.ListTransfersByIDs(context.Background(), transferIDs)
+	require.NoError(t, err)
+	require.Len(t, transfers, len(amounts))
+}
+
+func TestStatementTx(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
+
+	fromTime := time.Now()
+
+	_, err := testStore.TransferTx(context.Background(), &TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.StatementTx(context.Background(), &StatementTxParams{
+		AccountID: account1.ID,
+		FromTime:  fromTime,
+		ToTime:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+
+	closingBalance := result.Balances.OpeningBalance
+	for _, entry := range result.Entries {
+		closingBalance += entry.Amount
+	}
+	require.Equal(t, result.Balances.ClosingBalance, closingBalance)
+}
+
+func TestListAccountEntriesRunningBalanceAcrossPages(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
+
+	// debits of account1 interleaved with credits, which the direction filter skips
+	amounts := []int64{10, -5, 20, -7, 30}
+	for _, amount := range amounts {
+		arg := CreateTransferParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount}
+		if amount < 0 {
+			arg = CreateTransferParams{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: -amount}
+		}
+
+		_, err := testStore.TransferTx(context.Background(), &TransferTxParams{CreateTransferParams: arg})
+		require.NoError(t, err)
+	}
+
+	debit := util.DebitDirection
+	arg := ListAccountEntriesParams{
+		AccountID: account1.ID,
+		FromTime:  time.Now().Add(-time.Minute),
+		ToTime:    time.Now().Add(time.Minute),
+		Direction: &debit,
+		PageLimit: 1,
+	}
+
+	balance := account1.Balance
+	for _, amount := range amounts {
+		balance -= amount
+		if amount < 0 {
+			continue
+		}
+
+		entries, err := testStore.ListAccountEntries(context.Background(), &arg)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, -amount, entries[0].Amount)
+		require.Equal(t, balance, entries[0].RunningBalance)
+
+		arg.CursorCreatedAt = entries[0].CreatedAt
+		arg.CursorID = entries[0].ID
+	}
+
+	entries, err := testStore.ListAccountEntries(context.Background(), &arg)
+	require.NoError(t, err)
+	require.Empty(t, entries)
 }
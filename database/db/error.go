@@ -9,8 +9,21 @@ import (
 const (
 	ForeingKeyViolation = "23503"
 	UniqueViolation     = "23505"
+	CheckViolation      = "23514"
 )
 
+// ErrInsufficientFunds is returned when a transfer would take the source
+// account below its overdraft limit
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrIdempotencyKeyConflict is returned when an idempotency key is reused
+// with a different request body
+var ErrIdempotencyKeyConflict = errors.New("idempotency key already used for a different request")
+
+// ErrRefreshTokenReused is returned when a refresh token whose session was
+// already rotated is presented again
+var ErrRefreshTokenReused = errors.New("refresh token already used")
+
 var ErrUniqueViolation = &pgconn.PgError{
 	Code: UniqueViolation,
 }
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordLoginFailure(t *testing.T) {
+	key := "user:" + util.RandomOwner()
+
+	for i := 1; i <= 3; i++ {
+		attempt, err := testStore.RecordLoginFailure(context.Background(), &RecordLoginFailureParams{
+			Key:         key,
+			WindowStart: time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(i), attempt.Failures)
+	}
+
+	// failures before the window start are forgotten
+	attempt, err := testStore.RecordLoginFailure(context.Background(), &RecordLoginFailureParams{
+		Key:         key,
+		WindowStart: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), attempt.Failures)
+
+	stored, err := testStore.GetLoginAttempt(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, attempt.Failures, stored.Failures)
+	require.WithinDuration(t, attempt.LastFailureAt, stored.LastFailureAt, time.Second)
+
+	err = testStore.DeleteLoginAttempt(context.Background(), key)
+	require.NoError(t, err)
+
+	_, err = testStore.GetLoginAttempt(context.Background(), key)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
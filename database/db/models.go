@@ -8,14 +8,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Account struct {
-	ID        int64     `db:"id" json:"id"`
-	Owner     string    `db:"owner" json:"owner"`
-	Balance   int64     `db:"balance" json:"balance"`
-	Currency  string    `db:"currency" json:"currency"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	ID    int64  `db:"id" json:"id"`
+	Owner string `db:"owner" json:"owner"`
+	// must not go below -overdraft_limit
+	Balance        int64     `db:"balance" json:"balance"`
+	Currency       string    `db:"currency" json:"currency"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	OverdraftLimit int64     `db:"overdraft_limit" json:"overdraft_limit"`
 }
 
 type Entry struct {
@@ -24,17 +27,58 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `db:"amount" json:"amount"`
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	// null for entries not created by a transfer
+	TransferID *int64 `db:"transfer_id" json:"transfer_id"`
+}
+
+type IdempotencyKey struct {
+	Username    string    `db:"username" json:"username"`
+	Key         string    `db:"key" json:"key"`
+	RequestHash string    `db:"request_hash" json:"request_hash"`
+	Response    []byte    `db:"response" json:"response"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+type LoginAttempt struct {
+	Key           string    `db:"key" json:"key"`
+	Failures      int32     `db:"failures" json:"failures"`
+	LastFailureAt time.Time `db:"last_failure_at" json:"last_failure_at"`
+}
+
+type PasswordReset struct {
+	ID         int64     `db:"id" json:"id"`
+	Username   string    `db:"username" json:"username"`
+	SecretCode string    `db:"secret_code" json:"secret_code"`
+	IsUsed     bool      `db:"is_used" json:"is_used"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	ExpiredAt  time.Time `db:"expired_at" json:"expired_at"`
+}
+
+type RecoveryCode struct {
+	ID         int64     `db:"id" json:"id"`
+	Username   string    `db:"username" json:"username"`
+	HashedCode string    `db:"hashed_code" json:"hashed_code"`
+	IsUsed     bool      `db:"is_used" json:"is_used"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+type RolePermission struct {
+	Role       string    `db:"role" json:"role"`
+	Permission string    `db:"permission" json:"permission"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
 }
 
 type Session struct {
-	ID           uuid.UUID `db:"id" json:"id"`
-	Username     string    `db:"username" json:"username"`
-	RefreshToken string    `db:"refresh_token" json:"refresh_token"`
-	UserAgent    string    `db:"user_agent" json:"user_agent"`
-	ClientIp     string    `db:"client_ip" json:"client_ip"`
-	IsBlocked    bool      `db:"is_blocked" json:"is_blocked"`
-	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+	Username     string             `db:"username" json:"username"`
+	RefreshToken string             `db:"refresh_token" json:"refresh_token"`
+	UserAgent    string             `db:"user_agent" json:"user_agent"`
+	ClientIp     string             `db:"client_ip" json:"client_ip"`
+	IsBlocked    bool               `db:"is_blocked" json:"is_blocked"`
+	ExpiresAt    time.Time          `db:"expires_at" json:"expires_at"`
+	CreatedAt    time.Time          `db:"created_at" json:"created_at"`
+	FamilyID     uuid.UUID          `db:"family_id" json:"family_id"`
+	RotatedAt    pgtype.Timestamptz `db:"rotated_at" json:"rotated_at"`
 }
 
 type Transfer struct {
@@ -47,14 +91,20 @@ type Transfer struct {
 }
 
 type User struct {
-	Username          string    `db:"username" json:"username"`
-	HashedPassword    string    `db:"hashed_password" json:"hashed_password"`
-	FullName          string    `db:"full_name" json:"full_name"`
-	Email             string    `db:"email" json:"email"`
-	PasswordChangedAt time.Time `db:"password_changed_at" json:"password_changed_at"`
-	CreatedAt         time.Time `db:"created_at" json:"created_at"`
-	IsEmailVerified   bool      `db:"is_email_verified" json:"is_email_verified"`
-	Role              string    `db:"role" json:"role"`
+	Username               string    `db:"username" json:"username"`
+	HashedPassword         string    `db:"hashed_password" json:"hashed_password"`
+	FullName               string    `db:"full_name" json:"full_name"`
+	Email                  string    `db:"email" json:"email"`
+	PasswordChangedAt      time.Time `db:"password_changed_at" json:"password_changed_at"`
+	CreatedAt              time.Time `db:"created_at" json:"created_at"`
+	IsEmailVerified        bool      `db:"is_email_verified" json:"is_email_verified"`
+	Role                   string    `db:"role" json:"role"`
+	MonthlyStatementOptOut bool      `db:"monthly_statement_opt_out" json:"monthly_statement_opt_out"`
+	TotpSecret             string    `db:"totp_secret" json:"totp_secret"`
+	IsTotpEnabled          bool      `db:"is_totp_enabled" json:"is_totp_enabled"`
+	IsDisabled             bool      `db:"is_disabled" json:"is_disabled"`
+	TotpLastStep           int64     `db:"totp_last_step" json:"totp_last_step"`
+	RoleChangedAt          time.Time `db:"role_changed_at" json:"role_changed_at"`
 }
 
 type VerifyEmail struct {
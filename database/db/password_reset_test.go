@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetPasswordTx(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username, uuid.Nil)
+
+	passwordReset, err := testStore.CreatePasswordReset(context.Background(), &CreatePasswordResetParams{
+		Username:   user.Username,
+		SecretCode: util.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.False(t, passwordReset.IsUsed)
+
+	otherReset, err := testStore.CreatePasswordReset(context.Background(), &CreatePasswordResetParams{
+		Username:   user.Username,
+		SecretCode: util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	hashedPassword, err := util.HashedPassword(util.RandomString(8))
+	require.NoError(t, err)
+
+	arg := &ResetPasswordTxParams{
+		ResetId:        passwordReset.ID,
+		SecretCode:     passwordReset.SecretCode,
+		HashedPassword: hashedPassword,
+	}
+
+	result, err := testStore.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.PasswordReset.IsUsed)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+	require.True(t, result.User.PasswordChangedAt.After(user.PasswordChangedAt))
+
+	blockedSession, err := testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blockedSession.IsBlocked)
+
+	// a reset link can only be used once
+	_, err = testStore.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// the other links sent to the user stop working too
+	_, err = testStore.ResetPasswordTx(context.Background(), &ResetPasswordTxParams{
+		ResetId:        otherReset.ID,
+		SecretCode:     otherReset.SecretCode,
+		HashedPassword: hashedPassword,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
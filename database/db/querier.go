@@ -12,29 +12,62 @@ import (
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg *AddAccountBalanceParams) (*Account, error)
+	BlockSession(ctx context.Context, id uuid.UUID) (*Session, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
 	CreateAccount(ctx context.Context, arg *CreateAccountParams) (*Account, error)
 	CreateEntry(ctx context.Context, arg *CreateEntryParams) (*Entry, error)
+	CreateIdempotencyKey(ctx context.Context, arg *CreateIdempotencyKeyParams) (*IdempotencyKey, error)
+	CreatePasswordReset(ctx context.Context, arg *CreatePasswordResetParams) (*PasswordReset, error)
+	CreateRecoveryCode(ctx context.Context, arg *CreateRecoveryCodeParams) (*RecoveryCode, error)
+	CreateRolePermission(ctx context.Context, arg *CreateRolePermissionParams) (*RolePermission, error)
 	CreateSession(ctx context.Context, arg *CreateSessionParams) (*Session, error)
 	CreateTransfer(ctx context.Context, arg *CreateTransferParams) (*Transfer, error)
 	CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error)
 	CreateVerifyEmail(ctx context.Context, arg *CreateVerifyEmailParams) (*VerifyEmail, error)
 	DeleteAccount(ctx context.Context, id int64) error
 	DeleteEntry(ctx context.Context, id int64) error
+	DeleteLoginAttempt(ctx context.Context, key string) error
+	DeleteRecoveryCodes(ctx context.Context, username string) error
+	DeleteRolePermissions(ctx context.Context, role string) error
 	DeleteTransfer(ctx context.Context, id int64) error
+	EnableUserTOTP(ctx context.Context, arg *EnableUserTOTPParams) (*User, error)
 	GetAccount(ctx context.Context, id int64) (*Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (*Account, error)
+	GetAccountStatementBalances(ctx context.Context, arg *GetAccountStatementBalancesParams) (*GetAccountStatementBalancesRow, error)
 	GetEntry(ctx context.Context, id int64) (*Entry, error)
+	GetIdempotencyKey(ctx context.Context, arg *GetIdempotencyKeyParams) (*IdempotencyKey, error)
+	GetLoginAttempt(ctx context.Context, key string) (*LoginAttempt, error)
 	GetSession(ctx context.Context, id uuid.UUID) (*Session, error)
 	GetTransfer(ctx context.Context, id int64) (*Transfer, error)
 	GetUser(ctx context.Context, username string) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	InvalidatePasswordResets(ctx context.Context, username string) error
+	ListAccountEntries(ctx context.Context, arg *ListAccountEntriesParams) ([]*ListAccountEntriesRow, error)
 	ListAccounts(ctx context.Context, arg *ListAccountsParams) ([]*Account, error)
 	ListEntries(ctx context.Context, arg *ListEntriesParams) ([]*Entry, error)
+	ListRolePermissions(ctx context.Context, role string) ([]*RolePermission, error)
+	ListSessions(ctx context.Context, username string) ([]*Session, error)
+	ListStatementAccounts(ctx context.Context, arg *ListStatementAccountsParams) ([]*Account, error)
+	ListStatementEntries(ctx context.Context, arg *ListStatementEntriesParams) ([]*Entry, error)
 	ListTransfers(ctx context.Context, arg *ListTransfersParams) ([]*Transfer, error)
+	ListTransfersByIDs(ctx context.Context, ids []int64) ([]*Transfer, error)
+	ListUnusedRecoveryCodes(ctx context.Context, username string) ([]*RecoveryCode, error)
+	ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User, error)
+	RecordLoginFailure(ctx context.Context, arg *RecordLoginFailureParams) (*LoginAttempt, error)
+	RotateSession(ctx context.Context, id uuid.UUID) (*Session, error)
 	UpdateAccount(ctx context.Context, arg *UpdateAccountParams) (*Account, error)
 	UpdateEntry(ctx context.Context, arg *UpdateEntryParams) (*Entry, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg *UpdateIdempotencyKeyResponseParams) error
+	UpdatePasswordReset(ctx context.Context, arg *UpdatePasswordResetParams) (*PasswordReset, error)
 	UpdateTransfer(ctx context.Context, arg *UpdateTransferParams) (*Transfer, error)
 	UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error)
+	UpdateUserDisabled(ctx context.Context, arg *UpdateUserDisabledParams) (*User, error)
+	UpdateUserRole(ctx context.Context, arg *UpdateUserRoleParams) (*User, error)
+	UpdateUserTOTPLastStep(ctx context.Context, arg *UpdateUserTOTPLastStepParams) (*User, error)
+	UpdateUserTOTPSecret(ctx context.Context, arg *UpdateUserTOTPSecretParams) (*User, error)
 	UpdateVerifyEmail(ctx context.Context, arg *UpdateVerifyEmailParams) (*VerifyEmail, error)
+	UseRecoveryCode(ctx context.Context, id int64) (*RecoveryCode, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmTOTPTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testStore.EnableUserTOTP(context.Background(), &EnableUserTOTPParams{Username: user.Username})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	user, err = testStore.UpdateUserTOTPSecret(context.Background(), &UpdateUserTOTPSecretParams{
+		Username:   user.Username,
+		TotpSecret: util.RandomString(32),
+	})
+	require.NoError(t, err)
+	require.False(t, user.IsTotpEnabled)
+
+	hashedCodes := []string{util.RandomString(10), util.RandomString(10)}
+
+	result, err := testStore.ConfirmTOTPTx(context.Background(), &ConfirmTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: hashedCodes,
+		TotpLastStep:        100,
+	})
+	require.NoError(t, err)
+	require.True(t, result.User.IsTotpEnabled)
+	require.Equal(t, int64(100), result.User.TotpLastStep)
+
+	// a time step is accepted only once
+	arg := &UpdateUserTOTPLastStepParams{
+		Username:     user.Username,
+		TotpLastStep: 100,
+		TotpSecret:   user.TotpSecret,
+	}
+	_, err = testStore.UpdateUserTOTPLastStep(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	arg.TotpLastStep = 101
+	updatedUser, err := testStore.UpdateUserTOTPLastStep(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(101), updatedUser.TotpLastStep)
+
+	codes, err := testStore.ListUnusedRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, codes, len(hashedCodes))
+
+	usedCode, err := testStore.UseRecoveryCode(context.Background(), codes[0].ID)
+	require.NoError(t, err)
+	require.True(t, usedCode.IsUsed)
+
+	_, err = testStore.UseRecoveryCode(context.Background(), codes[0].ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	codes, err = testStore.ListUnusedRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, codes, len(hashedCodes)-1)
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeededRolePermissions(t *testing.T) {
+	permissions, err := testStore.ListRolePermissions(context.Background(), util.SupportRole)
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	require.Equal(t, util.ReadAnyAccountPermission, permissions[0].Permission)
+}
+
+func TestUpdateRolePermissionsTx(t *testing.T) {
+	role := "role_" + util.RandomOwner()
+
+	result, err := testStore.UpdateRolePermissionsTx(context.Background(), &UpdateRolePermissionsTxParams{
+		Role:        role,
+		Permissions: []string{util.ReadAnyAccountPermission, util.ManageUsersPermission},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.RolePermissions, 2)
+
+	result, err = testStore.UpdateRolePermissionsTx(context.Background(), &UpdateRolePermissionsTxParams{
+		Role:        role,
+		Permissions: []string{util.CreateTransferPermission},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.RolePermissions, 1)
+
+	permissions, err := testStore.ListRolePermissions(context.Background(), role)
+	require.NoError(t, err)
+	require.Len(t, permissions, 1)
+	require.Equal(t, role, permissions[0].Role)
+	require.Equal(t, util.CreateTransferPermission, permissions[0].Permission)
+	require.NotZero(t, permissions[0].CreatedAt)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSession(t *testing.T, username string, familyID uuid.UUID) *Session {
+	id := uuid.New()
+	if familyID == uuid.Nil {
+		familyID = id
+	}
+
+	arg := CreateSessionParams{
+		ID:           id,
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    util.RandomString(10),
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     familyID,
+	}
+
+	session, err := testStore.CreateSession(context.Background(), &arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.FamilyID, session.FamilyID)
+	require.False(t, session.RotatedAt.Valid)
+
+	return session
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user.Username, uuid.Nil)
+
+	arg := RotateSessionTxParams{
+		SessionID: session1.ID,
+		CreateSessionParams: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    session1.ExpiresAt,
+			FamilyID:     session1.FamilyID,
+		},
+	}
+
+	result, err := testStore.RotateSessionTx(context.Background(), &arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, result.Session.ID)
+	require.Equal(t, session1.FamilyID, result.Session.FamilyID)
+
+	rotated, err := testStore.GetSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+	require.True(t, rotated.RotatedAt.Valid)
+
+	// rotating the same session again is a reuse
+	arg.ID = uuid.New()
+	_, err = testStore.RotateSessionTx(context.Background(), &arg)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, err = testStore.GetSession(context.Background(), arg.ID)
+	require.Error(t, err)
+
+	blocked, err := testStore.BlockSessionFamily(context.Background(), session1.FamilyID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), blocked)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user.Username, uuid.Nil)
+	createRandomSession(t, user.Username, uuid.Nil)
+
+	sessions, err := testStore.ListSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	session, err := testStore.BlockSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	blocked, err := testStore.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), blocked)
+}
+
+func TestListSessionsHidesRotatedAndBlocked(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user.Username, uuid.Nil)
+	session2 := createRandomSession(t, user.Username, uuid.Nil)
+
+	// only the live descendant of a rotated session is listed
+	result, err := testStore.RotateSessionTx(context.Background(), &RotateSessionTxParams{
+		SessionID: session1.ID,
+		CreateSessionParams: CreateSessionParams{
+			ID:           uuid.New(),
+			Username:     user.Username,
+			RefreshToken: util.RandomString(32),
+			ExpiresAt:    session1.ExpiresAt,
+			FamilyID:     session1.FamilyID,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.BlockSessionFamily(context.Background(), session2.FamilyID)
+	require.NoError(t, err)
+
+	sessions, err := testStore.ListSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, result.Session.ID, sessions[0].ID)
+}
@@ -3,14 +3,22 @@ package db
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Store interface {
 	Querier
-	TransferTx(ctx context.Context, arg *CreateTransferParams) (*TransferTxResult, error)
+	TransferTx(ctx context.Context, arg *TransferTxParams) (*TransferTxResult, error)
 	CreateUserTx(ctx context.Context, arg *CreateUserTxParams) (*CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg *VerifyEmailTxParams) (*VerifyEmailTxResult, error)
+	RotateSessionTx(ctx context.Context, arg *RotateSessionTxParams) (*RotateSessionTxResult, error)
+	ConfirmTOTPTx(ctx context.Context, arg *ConfirmTOTPTxParams) (*ConfirmTOTPTxResult, error)
+	ResetPasswordTx(ctx context.Context, arg *ResetPasswordTxParams) (*ResetPasswordTxResult, error)
+	UpdateRolePermissionsTx(ctx context.Context, arg *UpdateRolePermissionsTxParams) (*UpdateRolePermissionsTxResult, error)
+	ChangeUserRoleTx(ctx context.Context, arg *ChangeUserRoleTxParams) (*ChangeUserRoleTxResult, error)
+	DisableUserTx(ctx context.Context, arg *DisableUserTxParams) (*DisableUserTxResult, error)
+	StatementTx(ctx context.Context, arg *StatementTxParams) (*StatementTxResult, error)
 }
 
 // Store provides all functions to execute db queries and transactions
@@ -29,7 +37,13 @@ func NewStore(db *pgxpool.Pool) Store {
 
 // execTx executes a function within a database transaction
 func (s *SqlStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := s.db.Begin(ctx)
+	return s.execTxWithOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// execTxWithOptions executes a function within a database transaction with the given
+// isolation level and access mode
+func (s *SqlStore) execTxWithOptions(ctx context.Context, options pgx.TxOptions, fn func(*Queries) error) error {
+	tx, err := s.db.BeginTx(ctx, options)
 	if err != nil {
 		return err
 	}
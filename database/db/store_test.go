@@ -3,14 +3,28 @@ package db
 import (
 	"context"
 	"fmt"
+	"main/util"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+func createFundedAccount(t *testing.T, balance int64) Account {
+	account := createRandomAccount(t)
+
+	fundedAccount, err := testStore.UpdateAccount(context.Background(), &UpdateAccountParams{
+		ID:      account.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+	require.Equal(t, balance, fundedAccount.Balance)
+
+	return *fundedAccount
+}
+
 func TestTransferTx(t *testing.T) {
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	// run n concurrent transfer transactions
@@ -21,10 +35,12 @@ func TestTransferTx(t *testing.T) {
 
 	for i := 0; i < n; i++ {
 		go func() {
-			result, err := testStore.TransferTx(context.Background(), &CreateTransferParams{
-				FromAccountID: account1.ID,
-				ToAccountID:   account2.ID,
-				Amount:        amount,
+			result, err := testStore.TransferTx(context.Background(), &TransferTxParams{
+				CreateTransferParams: CreateTransferParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+				},
 			})
 
 			errs <- err
@@ -111,8 +127,8 @@ func TestTransferTx(t *testing.T) {
 }
 
 func TestTransferTxDeadlock(t *testing.T) {
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
 	fmt.Println(">> before:", account1.Balance, account2.Balance)
 
 	// run n concurrent transfer transactions
@@ -130,10 +146,12 @@ func TestTransferTxDeadlock(t *testing.T) {
 		}
 
 		go func() {
-			_, err := testStore.TransferTx(context.Background(), &CreateTransferParams{
-				FromAccountID: fromAccountID,
-				ToAccountID:   toAccountID,
-				Amount:        amount,
+			_, err := testStore.TransferTx(context.Background(), &TransferTxParams{
+				CreateTransferParams: CreateTransferParams{
+					FromAccountID: fromAccountID,
+					ToAccountID:   toAccountID,
+					Amount:        amount,
+				},
 			})
 
 			errs <- err
@@ -156,3 +174,64 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updateAccount1.Balance)
 	require.Equal(t, account2.Balance, updateAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	account1 := createFundedAccount(t, 5)
+	account2 := createFundedAccount(t, 5)
+
+	_, err := testStore.TransferTx(context.Background(), &TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// check the balances are unchanged
+	updateAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+
+	updateAccount2, err := testStore.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance, updateAccount1.Balance)
+	require.Equal(t, account2.Balance, updateAccount2.Balance)
+}
+
+func TestTransferTxIdempotent(t *testing.T) {
+	account1 := createFundedAccount(t, 1000)
+	account2 := createFundedAccount(t, 1000)
+
+	arg := &TransferTxParams{
+		CreateTransferParams: CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		},
+		Username:       account1.Owner,
+		IdempotencyKey: util.RandomString(16),
+	}
+
+	result1, err := testStore.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, result1.Replayed)
+
+	// replaying the same request returns the original result
+	result2, err := testStore.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result2.Replayed)
+	require.Equal(t, result1.Transfer.ID, result2.Transfer.ID)
+	require.Equal(t, result1.FromEntry.ID, result2.FromEntry.ID)
+	require.Equal(t, result1.ToEntry.ID, result2.ToEntry.ID)
+
+	updateAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-arg.Amount, updateAccount1.Balance)
+
+	// reusing the key for a different request is a conflict
+	conflictArg := *arg
+	conflictArg.Amount = 20
+	_, err = testStore.TransferTx(context.Background(), &conflictArg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
@@ -65,25 +66,27 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (*Transfer, error)
 
 const listTransfers = `-- name: ListTransfers :many
 SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE from_account_id = $1 OR to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4
+WHERE (from_account_id = $1 OR to_account_id = $2)
+  AND (created_at, id) > ($3::timestamptz, $4::bigint)
+ORDER BY created_at, id
+LIMIT $5
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `db:"from_account_id" json:"from_account_id"`
-	ToAccountID   int64 `db:"to_account_id" json:"to_account_id"`
-	Limit         int32 `db:"limit" json:"limit"`
-	Offset        int32 `db:"offset" json:"offset"`
+	FromAccountID   int64     `db:"from_account_id" json:"from_account_id"`
+	ToAccountID     int64     `db:"to_account_id" json:"to_account_id"`
+	CursorCreatedAt time.Time `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorID        int64     `db:"cursor_id" json:"cursor_id"`
+	PageLimit       int32     `db:"page_limit" json:"page_limit"`
 }
 
 func (q *Queries) ListTransfers(ctx context.Context, arg *ListTransfersParams) ([]*Transfer, error) {
 	rows, err := q.db.Query(ctx, listTransfers,
 		arg.FromAccountID,
 		arg.ToAccountID,
-		arg.Limit,
-		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
 	)
 	if err != nil {
 		return nil, err
@@ -109,6 +112,38 @@ func (q *Queries) ListTransfers(ctx context.Context, arg *ListTransfersParams) (
 	return items, nil
 }
 
+const listTransfersByIDs = `-- name: ListTransfersByIDs :many
+SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+WHERE id = ANY($1::bigint[])
+ORDER BY id
+`
+
+func (q *Queries) ListTransfersByIDs(ctx context.Context, ids []int64) ([]*Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateTransfer = `-- name: UpdateTransfer :one
 UPDATE transfers
 SET from_account_id = $2, to_account_id = $3, amount = $4
@@ -103,10 +103,17 @@ func TestListTransfers(t *testing.T) {
 	arg := ListTransfersParams{
 		FromAccountID: account1.ID,
 		ToAccountID:   account1.ID,
-		Limit:         5,
-		Offset:        5,
+		PageLimit:     5,
 	}
 
+	firstPage, err := testStore.ListTransfers(context.Background(), &arg)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 5)
+
+	last := firstPage[len(firstPage)-1]
+	arg.CursorCreatedAt = last.CreatedAt
+	arg.CursorID = last.ID
+
 	transfers, err := testStore.ListTransfers(context.Background(), &arg)
 	require.NoError(t, err)
 	require.Len(t, transfers, 5)
@@ -0,0 +1,35 @@
+package db
+
+import "context"
+
+type ChangeUserRoleTxParams struct {
+	Username string
+	Role     string
+}
+
+type ChangeUserRoleTxResult struct {
+	User *User
+}
+
+// ChangeUserRoleTx sets the role of the user and blocks all of its sessions,
+// since refresh tokens carry the role they were issued with
+func (s *SqlStore) ChangeUserRoleTx(ctx context.Context, arg *ChangeUserRoleTxParams) (*ChangeUserRoleTxResult, error) {
+	var result ChangeUserRoleTxResult
+
+	err := s.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.UpdateUserRole(ctx, &UpdateUserRoleParams{
+			Username: arg.Username,
+			Role:     arg.Role,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.BlockUserSessions(ctx, arg.Username)
+		return err
+	})
+
+	return &result, err
+}
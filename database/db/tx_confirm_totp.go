@@ -0,0 +1,50 @@
+package db
+
+import "context"
+
+type ConfirmTOTPTxParams struct {
+	Username            string
+	HashedRecoveryCodes []string
+	// TotpLastStep is the time step of the code that confirmed the enrollment
+	TotpLastStep int64
+}
+
+type ConfirmTOTPTxResult struct {
+	User *User
+}
+
+// ConfirmTOTPTx enables TOTP for the user and replaces its recovery codes with the given ones
+func (s *SqlStore) ConfirmTOTPTx(ctx context.Context, arg *ConfirmTOTPTxParams) (*ConfirmTOTPTxResult, error) {
+	var result ConfirmTOTPTxResult
+
+	err := s.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.EnableUserTOTP(ctx, &EnableUserTOTPParams{
+			Username:     arg.Username,
+			TotpLastStep: arg.TotpLastStep,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, hashedCode := range arg.HashedRecoveryCodes {
+			_, err = q.CreateRecoveryCode(ctx, &CreateRecoveryCodeParams{
+				Username:   arg.Username,
+				HashedCode: hashedCode,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return &result, err
+}
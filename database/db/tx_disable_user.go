@@ -0,0 +1,34 @@
+package db
+
+import "context"
+
+type DisableUserTxParams struct {
+	Username string
+}
+
+type DisableUserTxResult struct {
+	User *User
+}
+
+// DisableUserTx disables the user and blocks all of its sessions, so it can
+// neither log in nor renew its access tokens
+func (s *SqlStore) DisableUserTx(ctx context.Context, arg *DisableUserTxParams) (*DisableUserTxResult, error) {
+	var result DisableUserTxResult
+
+	err := s.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.UpdateUserDisabled(ctx, &UpdateUserDisabledParams{
+			Username:   arg.Username,
+			IsDisabled: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.BlockUserSessions(ctx, arg.Username)
+		return err
+	})
+
+	return &result, err
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ResetPasswordTxParams struct {
+	ResetId        int64
+	SecretCode     string
+	HashedPassword string
+}
+
+type ResetPasswordTxResult struct {
+	User          *User
+	PasswordReset *PasswordReset
+}
+
+// ResetPasswordTx consumes the password reset, invalidates the other resets of the user,
+// sets the new password and blocks every session of the user so stolen refresh tokens stop working
+func (s *SqlStore) ResetPasswordTx(ctx context.Context, arg *ResetPasswordTxParams) (*ResetPasswordTxResult, error) {
+	var result ResetPasswordTxResult
+
+	err := s.ExecTx(ctx, func(q *Queries) error {
+		passwordReset, err := q.UpdatePasswordReset(ctx, &UpdatePasswordResetParams{
+			ID:         arg.ResetId,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.InvalidatePasswordResets(ctx, passwordReset.Username)
+		if err != nil {
+			return err
+		}
+
+		user, err := q.UpdateUser(ctx, &UpdateUserParams{
+			Username:          passwordReset.Username,
+			HashedPassword:    &arg.HashedPassword,
+			PasswordChangedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.BlockUserSessions(ctx, user.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User = user
+		result.PasswordReset = passwordReset
+		return nil
+	})
+
+	return &result, err
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type RotateSessionTxParams struct {
+	SessionID uuid.UUID
+	CreateSessionParams
+}
+
+type RotateSessionTxResult struct {
+	Session *Session
+}
+
+// RotateSessionTx marks a session as rotated and creates its successor in the same family.
+// It returns ErrRefreshTokenReused if the session has already been rotated.
+func (s *SqlStore) RotateSessionTx(ctx context.Context, arg *RotateSessionTxParams) (*RotateSessionTxResult, error) {
+	var result RotateSessionTxResult
+
+	err := s.ExecTx(ctx, func(q *Queries) error {
+		_, err := q.RotateSession(ctx, arg.SessionID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrRefreshTokenReused
+			}
+			return err
+		}
+
+		result.Session, err = q.CreateSession(ctx, &arg.CreateSessionParams)
+		return err
+	})
+
+	return &result, err
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type StatementTxParams struct {
+	AccountID int64
+	FromTime  time.Time
+	ToTime    time.Time
+}
+
+type StatementTxResult struct {
+	Balances *GetAccountStatementBalancesRow
+	Entries  []*Entry
+}
+
+// StatementTx reads the balances and entries of a statement from one snapshot, so a
+// transfer committed in between cannot make the entries disagree with the balances
+func (s *SqlStore) StatementTx(ctx context.Context, arg *StatementTxParams) (*StatementTxResult, error) {
+	var result StatementTxResult
+
+	options := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := s.execTxWithOptions(ctx, options, func(q *Queries) error {
+		var err error
+
+		result.Balances, err = q.GetAccountStatementBalances(ctx, &GetAccountStatementBalancesParams{
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+			AccountID: arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Entries, err = q.ListStatementEntries(ctx, &ListStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.FromTime,
+			ToTime:    arg.ToTime,
+		})
+		return err
+	})
+
+	return &result, err
+}
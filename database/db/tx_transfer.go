@@ -1,6 +1,23 @@
 package db
 
-import "context"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TransferTxParams contains the input parameters of the transfer transaction.
+// When IdempotencyKey is set, the result is stored under (Username, IdempotencyKey)
+// and replayed for any retry of the same request
+type TransferTxParams struct {
+	CreateTransferParams
+	Username       string
+	IdempotencyKey string
+}
 
 // TransferTxResult is the result of the transfer tracsaction
 type TransferTxResult struct {
@@ -9,50 +26,72 @@ type TransferTxResult struct {
 	ToEntry     Entry    `json:"to_entry"`
 	FromAccount Account  `json:"from_account"`
 	ToAccount   Account  `jsno:"to_account"`
+	// Replayed is true when the result of an earlier request with the same idempotency key was returned
+	Replayed bool `json:"-"`
 }
 
 // TransferTx performs a money transfer from one account to the other.
 // It creates a transfer record, add account entries, and update accounts'
-// balance within a single database transaction
-func (s *SqlStore) TransferTx(ctx context.Context, arg *CreateTransferParams) (*TransferTxResult, error) {
+// balance within a single database transaction. It returns ErrInsufficientFunds
+// if the source account balance would go below its overdraft limit, and
+// ErrIdempotencyKeyConflict if the idempotency key was used for another request
+func (s *SqlStore) TransferTx(ctx context.Context, arg *TransferTxParams) (*TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := s.ExecTx(ctx, func(q *Queries) error {
 
-		transfer, err := q.CreateTransfer(ctx, arg)
+		if arg.IdempotencyKey != "" {
+			replayed, err := claimIdempotencyKey(ctx, q, arg, &result)
+			if err != nil || replayed {
+				result.Replayed = replayed
+				return err
+			}
+		}
+
+		fromAccount, err := lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		if fromAccount.Balance-arg.Amount < -fromAccount.OverdraftLimit {
+			return ErrInsufficientFunds
+		}
+
+		transfer, err := q.CreateTransfer(ctx, &arg.CreateTransferParams)
 		if err != nil {
 			return err
 		}
 
 		fromEntry, err := q.CreateEntry(ctx, &CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
+			AccountID:  arg.FromAccountID,
+			Amount:     -arg.Amount,
+			TransferID: &transfer.ID,
 		})
 		if err != nil {
 			return err
 		}
 
 		toEntry, err := q.CreateEntry(ctx, &CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
+			AccountID:  arg.ToAccountID,
+			Amount:     arg.Amount,
+			TransferID: &transfer.ID,
 		})
 		if err != nil {
 			return err
 		}
 
-		var fromAccount *Account
 		var toAccount *Account
 
 		if arg.FromAccountID < arg.ToAccountID {
 			fromAccount, toAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
 		} else {
 			toAccount, fromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			if err != nil {
-				return err
+		}
+		if err != nil {
+			if ErrorCode(err) == CheckViolation {
+				return ErrInsufficientFunds
 			}
+			return err
 		}
 
 		result.Transfer = *transfer
@@ -61,12 +100,97 @@ func (s *SqlStore) TransferTx(ctx context.Context, arg *CreateTransferParams) (*
 		result.FromAccount = *fromAccount
 		result.ToAccount = *toAccount
 
+		if arg.IdempotencyKey != "" {
+			return saveIdempotencyKeyResponse(ctx, q, arg, &result)
+		}
+
 		return nil
 	})
 
 	return &result, err
 }
 
+// claimIdempotencyKey reserves the idempotency key for this transfer. If the key
+// was already used for the same request, the stored result is loaded into result
+// and replayed is true
+func claimIdempotencyKey(ctx context.Context, q *Queries, arg *TransferTxParams, result *TransferTxResult) (replayed bool, err error) {
+	requestHash, err := hashTransferRequest(&arg.CreateTransferParams)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = q.CreateIdempotencyKey(ctx, &CreateIdempotencyKeyParams{
+		Username:    arg.Username,
+		Key:         arg.IdempotencyKey,
+		RequestHash: requestHash,
+	})
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+
+	// the key exists, so this is a retry of an earlier request
+	idempotencyKey, err := q.GetIdempotencyKey(ctx, &GetIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.IdempotencyKey,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if idempotencyKey.RequestHash != requestHash || idempotencyKey.Response == nil {
+		return false, ErrIdempotencyKeyConflict
+	}
+
+	return true, json.Unmarshal(idempotencyKey.Response, result)
+}
+
+func saveIdempotencyKeyResponse(ctx context.Context, q *Queries, arg *TransferTxParams, result *TransferTxResult) error {
+	response, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return q.UpdateIdempotencyKeyResponse(ctx, &UpdateIdempotencyKeyResponseParams{
+		Username: arg.Username,
+		Key:      arg.IdempotencyKey,
+		Response: response,
+	})
+}
+
+func hashTransferRequest(arg *CreateTransferParams) (string, error) {
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// lockAccounts locks both accounts of a transfer in a consistent id order to
+// avoid deadlocks and returns the locked source account
+func lockAccounts(ctx context.Context, q *Queries, fromAccountID, toAccountID int64) (*Account, error) {
+	if fromAccountID < toAccountID {
+		fromAccount, err := q.GetAccountForUpdate(ctx, fromAccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = q.GetAccountForUpdate(ctx, toAccountID)
+		return fromAccount, err
+	}
+
+	_, err := q.GetAccountForUpdate(ctx, toAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.GetAccountForUpdate(ctx, fromAccountID)
+}
+
 func addMoney(ctx context.Context, q *Queries, accountID1, amount1, accountID2, amount2 int64) (account1 *Account, account2 *Account, err error) {
 	account1, err = q.AddAccountBalance(ctx, &AddAccountBalanceParams{
 		ID:     accountID1,
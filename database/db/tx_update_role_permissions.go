@@ -0,0 +1,41 @@
+package db
+
+import "context"
+
+type UpdateRolePermissionsTxParams struct {
+	Role        string
+	Permissions []string
+}
+
+type UpdateRolePermissionsTxResult struct {
+	RolePermissions []*RolePermission
+}
+
+// UpdateRolePermissionsTx replaces the permissions granted to the role with the given ones
+func (s *SqlStore) UpdateRolePermissionsTx(ctx context.Context, arg *UpdateRolePermissionsTxParams) (*UpdateRolePermissionsTxResult, error) {
+	var result UpdateRolePermissionsTxResult
+
+	err := s.ExecTx(ctx, func(q *Queries) error {
+		err := q.DeleteRolePermissions(ctx, arg.Role)
+		if err != nil {
+			return err
+		}
+
+		result.RolePermissions = make([]*RolePermission, 0, len(arg.Permissions))
+		for _, permission := range arg.Permissions {
+			rolePermission, err := q.CreateRolePermission(ctx, &CreateRolePermissionParams{
+				Role:       arg.Role,
+				Permission: permission,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.RolePermissions = append(result.RolePermissions, rolePermission)
+		}
+
+		return nil
+	})
+
+	return &result, err
+}
@@ -7,13 +7,14 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (username, hashed_password, full_name, email)
-VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+VALUES ($1, $2, $3, $4) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
 `
 
 type CreateUserParams struct {
@@ -40,12 +41,55 @@ func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
+	)
+	return &i, err
+}
+
+const enableUserTOTP = `-- name: EnableUserTOTP :one
+UPDATE users
+SET
+  is_totp_enabled = true,
+  totp_last_step = $2
+WHERE username = $1
+  AND totp_secret <> ''
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
+`
+
+type EnableUserTOTPParams struct {
+	Username     string `db:"username" json:"username"`
+	TotpLastStep int64  `db:"totp_last_step" json:"totp_last_step"`
+}
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, arg *EnableUserTOTPParams) (*User, error) {
+	row := q.db.QueryRow(ctx, enableUserTOTP, arg.Username, arg.TotpLastStep)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
 	)
 	return &i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role FROM users
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at FROM users
 WHERE username = $1
 ORDER BY username
 LIMIT 1
@@ -63,10 +107,111 @@ func (q *Queries) GetUser(ctx context.Context, username string) (*User, error) {
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
 	)
 	return &i, err
 }
 
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at FROM users
+WHERE email = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
+	)
+	return &i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at FROM users
+WHERE
+  (
+    $1::text IS NULL
+    OR strpos(lower(username), lower($1)) > 0
+    OR strpos(lower(full_name), lower($1)) > 0
+    OR strpos(lower(email), lower($1)) > 0
+  )
+  AND ($2::text IS NULL OR role = $2)
+  AND ($3::boolean IS NULL OR is_email_verified = $3)
+  AND (created_at, username) > ($4::timestamptz, $5::text)
+ORDER BY created_at, username
+LIMIT $6
+`
+
+type ListUsersParams struct {
+	Search          *string   `db:"search" json:"search"`
+	Role            *string   `db:"role" json:"role"`
+	IsEmailVerified *bool     `db:"is_email_verified" json:"is_email_verified"`
+	CursorCreatedAt time.Time `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorUsername  string    `db:"cursor_username" json:"cursor_username"`
+	PageLimit       int32     `db:"page_limit" json:"page_limit"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Search,
+		arg.Role,
+		arg.IsEmailVerified,
+		arg.CursorCreatedAt,
+		arg.CursorUsername,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.IsEmailVerified,
+			&i.Role,
+			&i.MonthlyStatementOptOut,
+			&i.TotpSecret,
+			&i.IsTotpEnabled,
+			&i.IsDisabled,
+			&i.TotpLastStep,
+			&i.RoleChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
@@ -74,19 +219,21 @@ SET
   password_changed_at = COALESCE($2, password_changed_at),
   full_name = COALESCE($3, full_name),
   email = COALESCE($4, email),
-  is_email_verified = COALESCE($5, is_email_verified)
+  is_email_verified = COALESCE($5, is_email_verified),
+  monthly_statement_opt_out = COALESCE($6, monthly_statement_opt_out)
 WHERE
-  username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+  username = $7
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
 `
 
 type UpdateUserParams struct {
-	HashedPassword    *string            `db:"hashed_password" json:"hashed_password"`
-	PasswordChangedAt pgtype.Timestamptz `db:"password_changed_at" json:"password_changed_at"`
-	FullName          *string            `db:"full_name" json:"full_name"`
-	Email             *string            `db:"email" json:"email"`
-	IsEmailVerified   *bool              `db:"is_email_verified" json:"is_email_verified"`
-	Username          string             `db:"username" json:"username"`
+	HashedPassword         *string            `db:"hashed_password" json:"hashed_password"`
+	PasswordChangedAt      pgtype.Timestamptz `db:"password_changed_at" json:"password_changed_at"`
+	FullName               *string            `db:"full_name" json:"full_name"`
+	Email                  *string            `db:"email" json:"email"`
+	IsEmailVerified        *bool              `db:"is_email_verified" json:"is_email_verified"`
+	MonthlyStatementOptOut *bool              `db:"monthly_statement_opt_out" json:"monthly_statement_opt_out"`
+	Username               string             `db:"username" json:"username"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error) {
@@ -96,6 +243,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User,
 		arg.FullName,
 		arg.Email,
 		arg.IsEmailVerified,
+		arg.MonthlyStatementOptOut,
 		arg.Username,
 	)
 	var i User
@@ -108,6 +256,157 @@ func (q *Queries) UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User,
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
+	)
+	return &i, err
+}
+
+const updateUserDisabled = `-- name: UpdateUserDisabled :one
+UPDATE users
+SET is_disabled = $2
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
+`
+
+type UpdateUserDisabledParams struct {
+	Username   string `db:"username" json:"username"`
+	IsDisabled bool   `db:"is_disabled" json:"is_disabled"`
+}
+
+func (q *Queries) UpdateUserDisabled(ctx context.Context, arg *UpdateUserDisabledParams) (*User, error) {
+	row := q.db.QueryRow(ctx, updateUserDisabled, arg.Username, arg.IsDisabled)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
+	)
+	return &i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET
+  role = $2,
+  role_changed_at = now()
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
+`
+
+type UpdateUserRoleParams struct {
+	Username string `db:"username" json:"username"`
+	Role     string `db:"role" json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg *UpdateUserRoleParams) (*User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.Username, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
+	)
+	return &i, err
+}
+
+const updateUserTOTPLastStep = `-- name: UpdateUserTOTPLastStep :one
+UPDATE users
+SET
+  totp_last_step = $1,
+  totp_secret = $2
+WHERE
+  username = $3
+  AND totp_last_step < $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
+`
+
+type UpdateUserTOTPLastStepParams struct {
+	TotpLastStep int64  `db:"totp_last_step" json:"totp_last_step"`
+	TotpSecret   string `db:"totp_secret" json:"totp_secret"`
+	Username     string `db:"username" json:"username"`
+}
+
+func (q *Queries) UpdateUserTOTPLastStep(ctx context.Context, arg *UpdateUserTOTPLastStepParams) (*User, error) {
+	row := q.db.QueryRow(ctx, updateUserTOTPLastStep, arg.TotpLastStep, arg.TotpSecret, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
+	)
+	return &i, err
+}
+
+const updateUserTOTPSecret = `-- name: UpdateUserTOTPSecret :one
+UPDATE users
+SET
+  totp_secret = $2,
+  is_totp_enabled = false
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, monthly_statement_opt_out, totp_secret, is_totp_enabled, is_disabled, totp_last_step, role_changed_at
+`
+
+type UpdateUserTOTPSecretParams struct {
+	Username   string `db:"username" json:"username"`
+	TotpSecret string `db:"totp_secret" json:"totp_secret"`
+}
+
+func (q *Queries) UpdateUserTOTPSecret(ctx context.Context, arg *UpdateUserTOTPSecretParams) (*User, error) {
+	row := q.db.QueryRow(ctx, updateUserTOTPSecret, arg.Username, arg.TotpSecret)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.MonthlyStatementOptOut,
+		&i.TotpSecret,
+		&i.IsTotpEnabled,
+		&i.IsDisabled,
+		&i.TotpLastStep,
+		&i.RoleChangedAt,
 	)
 	return &i, err
 }
@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"main/util"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -126,3 +128,87 @@ func TestUpdateUserOnlyAllFields(t *testing.T) {
 	require.Equal(t, newEmail, updatedUser.Email)
 	require.Equal(t, newHashedPassword, updatedUser.HashedPassword)
 }
+
+func TestListUsers(t *testing.T) {
+	user := createRandomUser(t)
+	for i := 0; i < 2; i++ {
+		createRandomUser(t)
+	}
+
+	search := strings.ToUpper(user.Username)
+	users, err := testStore.ListUsers(context.Background(), &ListUsersParams{
+		Search:    &search,
+		PageLimit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, user.Username, users[0].Username)
+
+	role := util.DepositorRole
+	verified := false
+	firstPage, err := testStore.ListUsers(context.Background(), &ListUsersParams{
+		Role:            &role,
+		IsEmailVerified: &verified,
+		PageLimit:       2,
+	})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+
+	last := firstPage[len(firstPage)-1]
+	nextPage, err := testStore.ListUsers(context.Background(), &ListUsersParams{
+		Role:            &role,
+		IsEmailVerified: &verified,
+		CursorCreatedAt: last.CreatedAt,
+		CursorUsername:  last.Username,
+		PageLimit:       2,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, nextPage)
+
+	for _, user := range nextPage {
+		require.Equal(t, role, user.Role)
+		require.False(t, user.IsEmailVerified)
+		require.NotEqual(t, last.Username, user.Username)
+	}
+}
+
+func TestChangeUserRoleTx(t *testing.T) {
+	user := createRandomUser(t)
+	require.True(t, user.RoleChangedAt.IsZero())
+	session := createRandomSession(t, user.Username, uuid.Nil)
+
+	result, err := testStore.ChangeUserRoleTx(context.Background(), &ChangeUserRoleTxParams{
+		Username: user.Username,
+		Role:     util.SupportRole,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.SupportRole, result.User.Role)
+	require.WithinDuration(t, time.Now(), result.User.RoleChangedAt, time.Second)
+
+	session, err = testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+}
+
+func TestDisableUserTx(t *testing.T) {
+	user := createRandomUser(t)
+	require.False(t, user.IsDisabled)
+	session := createRandomSession(t, user.Username, uuid.Nil)
+
+	result, err := testStore.DisableUserTx(context.Background(), &DisableUserTxParams{
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, result.User.IsDisabled)
+
+	session, err = testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	enabledUser, err := testStore.UpdateUserDisabled(context.Background(), &UpdateUserDisabledParams{
+		Username:   user.Username,
+		IsDisabled: false,
+	})
+	require.NoError(t, err)
+	require.False(t, enabledUser.IsDisabled)
+}
@@ -9,7 +9,13 @@ CREATE TABLE "users" (
   "full_name" text NOT NULL,
   "email" text UNIQUE NOT NULL,
   "is_email_verified" boolean NOT NULL DEFAULT false,
+  "monthly_statement_opt_out" boolean NOT NULL DEFAULT false,
+  "totp_secret" text NOT NULL DEFAULT '',
+  "is_totp_enabled" boolean NOT NULL DEFAULT false,
+  "totp_last_step" bigint NOT NULL DEFAULT 0,
+  "is_disabled" boolean NOT NULL DEFAULT false,
   "password_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "role_changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -23,11 +29,42 @@ CREATE TABLE "verify_emails" (
   "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
 );
 
+CREATE TABLE "password_resets" (
+  "id" bigserial PRIMARY KEY,
+  "username" text NOT NULL,
+  "secret_code" text NOT NULL,
+  "is_used" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expired_at" timestamptz NOT NULL DEFAULT (now() + interval '15 minutes')
+);
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" text NOT NULL,
+  "hashed_code" text NOT NULL,
+  "is_used" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "login_attempts" (
+  "key" text PRIMARY KEY,
+  "failures" integer NOT NULL DEFAULT 0,
+  "last_failure_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+  "role" text NOT NULL,
+  "permission" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
 CREATE TABLE "accounts" (
   "id" bigserial PRIMARY KEY,
   "owner" text NOT NULL,
   "balance" bigint NOT NULL,
   "currency" text NOT NULL,
+  "overdraft_limit" bigint NOT NULL DEFAULT 0,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -35,6 +72,7 @@ CREATE TABLE "entries" (
   "id" bigserial PRIMARY KEY,
   "account_id" bigint NOT NULL,
   "amount" bigint NOT NULL,
+  "transfer_id" bigint,
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
@@ -46,28 +84,65 @@ CREATE TABLE "transfers" (
   "created_at" timestamptz NOT NULL DEFAULT (now())
 );
 
+CREATE TABLE "idempotency_keys" (
+  "username" text NOT NULL,
+  "key" text NOT NULL,
+  "request_hash" text NOT NULL,
+  "response" jsonb,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "key")
+);
+
+CREATE INDEX ON "users" ("created_at", "username");
+
+CREATE INDEX ON "recovery_codes" ("username");
+
 CREATE INDEX ON "accounts" ("owner");
 
 CREATE UNIQUE INDEX ON "accounts" ("owner", "currency");
 
+CREATE INDEX ON "accounts" ("owner", "created_at", "id");
+
 CREATE INDEX ON "entries" ("account_id");
 
+CREATE INDEX ON "entries" ("account_id", "created_at", "id");
+
 CREATE INDEX ON "transfers" ("from_account_id");
 
 CREATE INDEX ON "transfers" ("to_account_id");
 
 CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
 
+CREATE INDEX ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at", "id");
+
+COMMENT ON COLUMN "accounts"."balance" IS 'must not go below -overdraft_limit';
+
 COMMENT ON COLUMN "entries"."amount" IS 'can be negative or positive';
 
+COMMENT ON COLUMN "entries"."transfer_id" IS 'null for entries not created by a transfer';
+
 COMMENT ON COLUMN "transfers"."amount" IS 'must be positive';
 
+ALTER TABLE "accounts" ADD CONSTRAINT "overdraft_limit_check" CHECK ("overdraft_limit" >= 0);
+
+ALTER TABLE "accounts" ADD CONSTRAINT "balance_overdraft_check" CHECK ("balance" >= -"overdraft_limit");
+
 ALTER TABLE "verify_emails" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
 
+ALTER TABLE "password_resets" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
 ALTER TABLE "accounts" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
 
 ALTER TABLE "entries" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
 
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
 ALTER TABLE "transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
 
 ALTER TABLE "transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
@@ -0,0 +1,7 @@
+COMMENT ON COLUMN "accounts"."balance" IS NULL;
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "balance_overdraft_check";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "overdraft_limit_check";
+
+ALTER TABLE "accounts" DROP COLUMN "overdraft_limit";
@@ -0,0 +1,10 @@
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit" bigint NOT NULL DEFAULT 0;
+
+-- accounts already overdrawn keep their balance as their limit, so the check below holds
+UPDATE "accounts" SET "overdraft_limit" = GREATEST(0, -"balance") WHERE "balance" < 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "overdraft_limit_check" CHECK ("overdraft_limit" >= 0);
+
+ALTER TABLE "accounts" ADD CONSTRAINT "balance_overdraft_check" CHECK ("balance" >= -"overdraft_limit");
+
+COMMENT ON COLUMN "accounts"."balance" IS 'must not go below -overdraft_limit';
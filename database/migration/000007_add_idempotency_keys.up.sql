@@ -0,0 +1,10 @@
+CREATE TABLE "idempotency_keys" (
+  "username" text NOT NULL,
+  "key" text NOT NULL,
+  "request_hash" text NOT NULL,
+  "response" jsonb,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "key")
+);
+
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
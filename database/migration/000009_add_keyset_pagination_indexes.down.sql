@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "transfers_to_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "entries_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "accounts_owner_created_at_id_idx";
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
@@ -0,0 +1 @@
+ALTER TABLE "users" DROP COLUMN "monthly_statement_opt_out";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "users" ADD COLUMN "monthly_statement_opt_out" boolean NOT NULL DEFAULT false;
\ No newline at end of file
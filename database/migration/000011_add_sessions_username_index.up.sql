@@ -0,0 +1 @@
+CREATE INDEX ON "sessions" ("username");
\ No newline at end of file
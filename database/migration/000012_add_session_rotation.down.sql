@@ -0,0 +1,3 @@
+ALTER TABLE "sessions" DROP COLUMN "rotated_at";
+
+ALTER TABLE "sessions" DROP COLUMN "family_id";
\ No newline at end of file
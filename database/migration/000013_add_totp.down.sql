@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "recovery_codes";
+
+ALTER TABLE "users" DROP COLUMN "is_totp_enabled";
+
+ALTER TABLE "users" DROP COLUMN "totp_secret";
\ No newline at end of file
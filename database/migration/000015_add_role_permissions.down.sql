@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "role_permissions";
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE "role_permissions" (
+  "role" text NOT NULL,
+  "permission" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("role", "permission")
+);
+
+INSERT INTO "role_permissions" ("role", "permission") VALUES
+  ('depositor', 'accounts:create'),
+  ('depositor', 'transfers:create'),
+  ('banker', 'accounts:create'),
+  ('banker', 'accounts:read:any'),
+  ('banker', 'accounts:delete:any'),
+  ('banker', 'transfers:create'),
+  ('banker', 'users:manage'),
+  ('banker', 'roles:manage'),
+  ('support', 'accounts:read:any');
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "users_created_at_username_idx";
+
+ALTER TABLE "users" DROP COLUMN "is_disabled";
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN "is_disabled" boolean NOT NULL DEFAULT false;
+
+CREATE INDEX ON "users" ("created_at", "username");
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TABLE "login_attempts" (
+  "key" text PRIMARY KEY,
+  "failures" integer NOT NULL DEFAULT 0,
+  "last_failure_at" timestamptz NOT NULL DEFAULT (now())
+);
\ No newline at end of file
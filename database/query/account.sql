@@ -17,10 +17,18 @@ FOR NO KEY UPDATE;
 
 -- name: ListAccounts :many
 SELECT * FROM accounts
-WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE owner = @owner
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT @page_limit;
+
+-- name: ListStatementAccounts :many
+SELECT a.* FROM accounts a
+JOIN users u ON u.username = a.owner
+WHERE NOT u.monthly_statement_opt_out
+  AND a.id > @cursor_id
+ORDER BY a.id
+LIMIT @page_limit;
 
 -- name: AddAccountBalance :one
 UPDATE accounts
@@ -1,6 +1,6 @@
 -- name: CreateEntry :one
-INSERT INTO entries (account_id, amount)
-VALUES ($1, $2) RETURNING *;
+INSERT INTO entries (account_id, amount, transfer_id)
+VALUES ($1, $2, $3) RETURNING *;
 
 -- name: GetEntry :one
 SELECT * FROM entries
@@ -10,10 +10,79 @@ LIMIT 1;
 
 -- name: ListEntries :many
 SELECT * FROM entries
-WHERE account_id = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE account_id = @account_id
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT @page_limit;
+
+-- name: ListStatementEntries :many
+SELECT * FROM entries
+WHERE account_id = @account_id
+  AND created_at >= @from_time
+  AND created_at < @to_time
+ORDER BY created_at, id;
+
+-- name: GetAccountStatementBalances :one
+SELECT
+  (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= sqlc.arg(from_time)::timestamptz), 0))::bigint AS opening_balance,
+  (a.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.created_at >= sqlc.arg(to_time)::timestamptz), 0))::bigint AS closing_balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+WHERE a.id = @account_id
+GROUP BY a.id;
+
+-- name: ListAccountEntries :many
+WITH page AS (
+  SELECT * FROM entries
+  WHERE account_id = @account_id
+    AND created_at >= @from_time
+    AND created_at < @to_time
+    AND (
+      sqlc.narg(direction)::text IS NULL
+      OR (sqlc.narg(direction)::text = 'debit' AND amount < 0)
+      OR (sqlc.narg(direction)::text = 'credit' AND amount > 0)
+    )
+    AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::bigint)
+  ORDER BY created_at, id
+  LIMIT @page_limit
+),
+-- every entry from the first to the last row of the page, including the filtered out ones
+span AS (
+  SELECT
+    e.id,
+    COALESCE(SUM(e.amount) OVER (
+      ORDER BY e.created_at DESC, e.id DESC
+      ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+    ), 0) AS newer_amount
+  FROM entries e
+  WHERE e.account_id = @account_id
+    AND (e.created_at, e.id) >= (SELECT created_at, id FROM page ORDER BY created_at, id LIMIT 1)
+    AND (e.created_at, e.id) <= (SELECT created_at, id FROM page ORDER BY created_at DESC, id DESC LIMIT 1)
+),
+-- the entries after the page, summed once through the (account_id, created_at, id) index
+after_page AS (
+  SELECT COALESCE(SUM(e.amount), 0) AS amount
+  FROM entries e
+  WHERE e.account_id = @account_id
+    AND (e.created_at, e.id) > (SELECT created_at, id FROM page ORDER BY created_at DESC, id DESC LIMIT 1)
+)
+SELECT
+  p.id,
+  p.account_id,
+  p.amount,
+  p.created_at,
+  p.transfer_id,
+  (a.balance - ap.amount - s.newer_amount)::bigint AS running_balance,
+  COALESCE(CASE
+    WHEN t.from_account_id = p.account_id THEN t.to_account_id
+    ELSE t.from_account_id
+  END, 0)::bigint AS counterparty_account_id
+FROM page p
+JOIN span s ON s.id = p.id
+JOIN accounts a ON a.id = p.account_id
+CROSS JOIN after_page ap
+LEFT JOIN transfers t ON t.id = p.transfer_id
+ORDER BY p.created_at, p.id;
 
 -- name: UpdateEntry :one
 UPDATE entries
@@ -0,0 +1,21 @@
+-- name: CreateRecoveryCode :one
+INSERT INTO recovery_codes (username, hashed_code)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: ListUnusedRecoveryCodes :many
+SELECT * FROM recovery_codes
+WHERE username = $1
+  AND is_used = false
+ORDER BY id;
+
+-- name: UseRecoveryCode :one
+UPDATE recovery_codes
+SET is_used = true
+WHERE id = $1
+  AND is_used = false
+RETURNING *;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE username = $1;
\ No newline at end of file
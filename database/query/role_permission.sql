@@ -0,0 +1,13 @@
+-- name: CreateRolePermission :one
+INSERT INTO role_permissions (role, permission)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: ListRolePermissions :many
+SELECT * FROM role_permissions
+WHERE role = $1
+ORDER BY permission;
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions
+WHERE role = $1;
\ No newline at end of file
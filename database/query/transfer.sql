@@ -10,10 +10,15 @@ LIMIT 1;
 
 -- name: ListTransfers :many
 SELECT * FROM transfers
-WHERE from_account_id = $1 OR to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4;
+WHERE (from_account_id = @from_account_id OR to_account_id = @to_account_id)
+  AND (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::bigint)
+ORDER BY created_at, id
+LIMIT @page_limit;
+
+-- name: ListTransfersByIDs :many
+SELECT * FROM transfers
+WHERE id = ANY(@ids::bigint[])
+ORDER BY id;
 
 -- name: UpdateTransfer :one
 UPDATE transfers
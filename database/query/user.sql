@@ -8,6 +8,26 @@ WHERE username = $1
 ORDER BY username
 LIMIT 1;
 
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1
+LIMIT 1;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE
+  (
+    sqlc.narg(search)::text IS NULL
+    OR strpos(lower(username), lower(sqlc.narg(search))) > 0
+    OR strpos(lower(full_name), lower(sqlc.narg(search))) > 0
+    OR strpos(lower(email), lower(sqlc.narg(search))) > 0
+  )
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role))
+  AND (sqlc.narg(is_email_verified)::boolean IS NULL OR is_email_verified = sqlc.narg(is_email_verified))
+  AND (created_at, username) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_username)::text)
+ORDER BY created_at, username
+LIMIT @page_limit;
+
 -- name: UpdateUser :one
 UPDATE users
 SET
@@ -15,7 +35,49 @@ SET
   password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
   full_name = COALESCE(sqlc.narg(full_name), full_name),
   email = COALESCE(sqlc.narg(email), email),
-  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+  monthly_statement_opt_out = COALESCE(sqlc.narg(monthly_statement_opt_out), monthly_statement_opt_out)
+WHERE
+  username = sqlc.arg(username)
+RETURNING *;
+
+-- name: UpdateUserTOTPSecret :one
+UPDATE users
+SET
+  totp_secret = $2,
+  is_totp_enabled = false
+WHERE username = $1
+RETURNING *;
+
+-- name: EnableUserTOTP :one
+UPDATE users
+SET
+  is_totp_enabled = true,
+  totp_last_step = $2
+WHERE username = $1
+  AND totp_secret <> ''
+RETURNING *;
+
+-- name: UpdateUserTOTPLastStep :one
+UPDATE users
+SET
+  totp_last_step = sqlc.arg(totp_last_step),
+  totp_secret = sqlc.arg(totp_secret)
 WHERE
   username = sqlc.arg(username)
+  AND totp_last_step < sqlc.arg(totp_last_step)
+RETURNING *;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET
+  role = $2,
+  role_changed_at = now()
+WHERE username = $1
+RETURNING *;
+
+-- name: UpdateUserDisabled :one
+UPDATE users
+SET is_disabled = $2
+WHERE username = $1
 RETURNING *;
\ No newline at end of file
@@ -3,10 +3,14 @@ package gapi
 import (
 	"context"
 	"fmt"
+	"main/logging"
 	"main/token"
 	"strings"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -14,46 +18,128 @@ const (
 	authorizationBearer = "bearer"
 )
 
-func (s *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+type authPayloadKey struct{}
+
+// AuthUnaryInterceptor authorizes unary calls with the policy of their method and
+// passes the verified token payload to the handler in the context
+func (s *Server) AuthUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// AuthStreamInterceptor authorizes streaming calls with the policy of their method
+func (s *Server) AuthStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+// contextStream is a server stream whose context was replaced by an interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *contextStream) Context() context.Context {
+	return stream.ctx
+}
+
+// authorize checks the call against the policy of the method. Methods without
+// a policy are denied, so new RPCs stay closed until they are added to the table
+func (s *Server) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	policy, ok := methodPolicies[fullMethod]
+	if !ok || policy.access == accessDenied {
+		return nil, status.Errorf(codes.PermissionDenied, "method %s is not allowed", fullMethod)
+	}
+
+	if policy.access == accessPublic {
+		return ctx, nil
+	}
+
+	accessToken, err := bearerToken(ctx)
+	if err != nil {
+		if policy.access == accessOptional && err == errMissingAuthorization {
+			return ctx, nil
+		}
+
+		return nil, unauthenticatedError(err)
+	}
+
+	payload, err := s.tokenMaker.VerifyToken(accessToken)
+	if err != nil {
+		return nil, unauthenticatedError(fmt.Errorf("invalid access token: %s", err))
+	}
+
+	if err := s.revocationChecker.Check(ctx, payload); err != nil {
+		return nil, unauthenticatedError(fmt.Errorf("invalid access token: %s", err))
+	}
+
+	if policy.permission != "" {
+		allowed, err := s.permissionChecker.HasPermission(ctx, payload.Role, policy.permission)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+		}
+
+		if !allowed {
+			return nil, status.Errorf(codes.PermissionDenied, "role %s is not allowed to call %s", payload.Role, fullMethod)
+		}
+	}
+
+	logging.SetUsername(ctx, payload.Username)
+	return context.WithValue(ctx, authPayloadKey{}, payload), nil
+}
+
+var errMissingAuthorization = fmt.Errorf("missing authorization header")
+
+// bearerToken returns the access token of the authorization header
+func bearerToken(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("missing metadata")
+		return "", errMissingAuthorization
 	}
 
 	values := md.Get(authorizationHeader)
 	if len(values) == 0 {
-		return nil, fmt.Errorf("missing authorization header")
+		return "", errMissingAuthorization
 	}
 
 	authHeader := values[0]
 	fields := strings.Fields(authHeader)
 	if len(fields) < 2 {
-		return nil, fmt.Errorf("invalid authorization header format")
+		return "", fmt.Errorf("invalid authorization header format")
 	}
 
 	authType := strings.ToLower(fields[0])
 	if authType != authorizationBearer {
-		return nil, fmt.Errorf("unsupported authorization type: %s", authType)
+		return "", fmt.Errorf("unsupported authorization type: %s", authType)
 	}
 
-	accessToken := fields[1]
-	payload, err := s.tokenMaker.VerifyToken(accessToken)
-	if err != nil {
-		return nil, fmt.Errorf("invalid access token: %s", err)
-	}
+	return fields[1], nil
+}
 
-	if !hasPermission(payload.Role, accessibleRoles) {
-		return nil, fmt.Errorf("permission denied")
+// authPayloadFromContext returns the token payload the auth interceptor verified for the call
+func authPayloadFromContext(ctx context.Context) (*token.Payload, error) {
+	payload, ok := ctx.Value(authPayloadKey{}).(*token.Payload)
+	if !ok {
+		return nil, fmt.Errorf("missing access token")
 	}
 
 	return payload, nil
 }
 
-func hasPermission(userRole string, accessibleRoles []string) bool {
-	for _, role := range accessibleRoles {
-		if userRole == role {
-			return true
-		}
+// isOwnerOrPermitted returns true if the resource of owner belongs to the authenticated
+// user, or if their role was granted the permission to act on resources of any user
+func (s *Server) isOwnerOrPermitted(ctx context.Context, payload *token.Payload, owner string, permission string) (bool, error) {
+	if owner == payload.Username {
+		return true, nil
 	}
-	return false
+
+	return s.permissionChecker.HasPermission(ctx, payload.Role, permission)
 }
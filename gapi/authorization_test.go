@@ -0,0 +1,190 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/database/mockdb"
+	"main/pb"
+	"main/revocation"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeUserPasswordChanged(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	storeCtrl := gomock.NewController(t)
+	defer storeCtrl.Finish()
+	store := mockdb.NewMockStore(storeCtrl)
+
+	changedUser := *user
+	changedUser.PasswordChangedAt = time.Now().Add(2 * time.Second)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(&changedUser, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store, nil)
+	server.revocationChecker = revocation.NewChecker(store, time.Minute)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+	_, err := callRPC(server, ctx, pb.SimpleBank_GetAccount_FullMethodName, &pb.GetAccountRequest{Id: account.ID}, server.GetAccount)
+	require.Error(t, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+}
+
+func TestMethodPolicies(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := fmt.Sprintf("/%s/%s", pb.SimpleBank_ServiceDesc.ServiceName, method.MethodName)
+		policy, ok := methodPolicies[fullMethod]
+		require.True(t, ok, "missing access policy for %s", fullMethod)
+		require.NotEqual(t, accessDenied, policy.access, fullMethod)
+	}
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	const bankerOnlyMethod = "/pb.SimpleBank/BankerOnly"
+	methodPolicies[bankerOnlyMethod] = methodPolicy{access: accessAuthenticated, permission: util.ManageUsersPermission}
+	t.Cleanup(func() {
+		delete(methodPolicies, bankerOnlyMethod)
+	})
+
+	testCases := []struct {
+		name         string
+		method       string
+		buildContext func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResult  func(t *testing.T, payload *token.Payload, err error)
+	}{
+		{
+			name:   "OK",
+			method: bankerOnlyMethod,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "banker", payload.Username)
+			},
+		},
+		{
+			name:   "PermissionNotGranted",
+			method: bankerOnlyMethod,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "depositor", util.DepositorRole, time.Minute)
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "ExpiredToken",
+			method: bankerOnlyMethod,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, -time.Minute)
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "MissingToken",
+			method: bankerOnlyMethod,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name:   "MethodWithoutPolicy",
+			method: "/pb.SimpleBank/NewMethod",
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name:   "PublicIgnoresToken",
+			method: pb.SimpleBank_LoginUser_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, -time.Minute)
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.Nil(t, payload)
+			},
+		},
+		{
+			name:   "OptionalWithoutToken",
+			method: pb.SimpleBank_EnrollTOTP_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				require.NoError(t, err)
+				require.Nil(t, payload)
+			},
+		},
+		{
+			name:   "OptionalWithInvalidToken",
+			method: pb.SimpleBank_EnrollTOTP_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "depositor", util.DepositorRole, -time.Minute)
+			},
+			checkResult: func(t *testing.T, payload *token.Payload, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, nil, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			var payload *token.Payload
+			handler := func(ctx context.Context, req any) (any, error) {
+				payload, _ = authPayloadFromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := server.AuthUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			tc.checkResult(t, payload, err)
+		})
+	}
+}
+
+func requireStatusCode(t *testing.T, code codes.Code, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+}
+
+func TestAuthRejectsRefreshToken(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+
+	// a refresh token is signed with its own key and is no bearer credential
+	ctx := newContextWithBearerToken(t, server.refreshTokenMaker, "banker", util.BankerRole, time.Minute)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	}
+
+	_, err := server.AuthUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: pb.SimpleBank_ListAccounts_FullMethodName}, handler)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
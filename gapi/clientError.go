@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"main/logging"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ClientErrorUnaryInterceptor keeps the detail of internal errors server-side: it logs
+// the error and returns a generic message with the request id to the client instead
+func ClientErrorUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, clientError(ctx, info.FullMethod, err)
+}
+
+// ClientErrorStreamInterceptor does for streaming calls what ClientErrorUnaryInterceptor does for unary calls
+func ClientErrorStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, stream)
+	return clientError(stream.Context(), info.FullMethod, err)
+}
+
+// clientError replaces internal and unknown errors, whose messages may hold database
+// or other internal detail, by an error the client can quote to find the log record
+func clientError(ctx context.Context, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// the client gave up or ran out of time, nothing failed on our side
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	st, _ := status.FromError(err)
+	if st.Code() != codes.Internal && st.Code() != codes.Unknown && st.Code() != codes.DataLoss {
+		return err
+	}
+
+	slog.LogAttrs(ctx, slog.LevelError, "internal error",
+		slog.String("method", method),
+		slog.String("status_text", st.Code().String()),
+		slog.String("error", st.Message()),
+	)
+
+	requestID := logging.RequestID(ctx)
+	statusInternal := status.New(codes.Internal, fmt.Sprintf("internal error, request id %s", requestID))
+
+	statusDetails, detailsErr := statusInternal.WithDetails(&errdetails.RequestInfo{RequestId: requestID})
+	if detailsErr != nil {
+		return statusInternal.Err()
+	}
+
+	return statusDetails.Err()
+}
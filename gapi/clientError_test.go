@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"main/logging"
+	"main/pb"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientErrorUnaryInterceptor(t *testing.T) {
+	var buffer bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(logging.NewLogger(&buffer, "production"))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	ctx := logging.NewContext(context.Background(), "abc-123")
+	info := &grpc.UnaryServerInfo{FullMethod: pb.SimpleBank_GetAccount_FullMethodName}
+
+	call := func(handlerErr error) error {
+		_, err := ClientErrorUnaryInterceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, handlerErr
+		})
+		return err
+	}
+
+	// internal errors reach the client as a generic message with the request id
+	pgErr := `failed to get account: ERROR: relation "accounts" does not exist (SQLSTATE 42P01)`
+	err := call(status.Errorf(codes.Internal, pgErr))
+	requireStatusCode(t, codes.Internal, err)
+
+	st := status.Convert(err)
+	require.Equal(t, "internal error, request id abc-123", st.Message())
+	require.Len(t, st.Details(), 1)
+	require.Equal(t, "abc-123", st.Details()[0].(*errdetails.RequestInfo).RequestId)
+
+	// the detail stays in the log record of the request
+	require.Contains(t, buffer.String(), `"request_id":"abc-123"`)
+	require.Contains(t, buffer.String(), "SQLSTATE 42P01")
+
+	// errors that are not status errors are internal too
+	err = call(errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+	requireStatusCode(t, codes.Internal, err)
+	require.NotContains(t, status.Convert(err).Message(), "10.0.0.5")
+
+	// client errors and cancellations are returned as they are
+	err = call(status.Errorf(codes.NotFound, "account not found"))
+	requireStatusCode(t, codes.NotFound, err)
+	require.Equal(t, "account not found", status.Convert(err).Message())
+
+	requireStatusCode(t, codes.Canceled, call(context.Canceled))
+	require.NoError(t, call(nil))
+}
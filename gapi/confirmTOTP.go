@@ -0,0 +1,93 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/totp"
+	"main/util"
+	"main/validate"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	authPayload, err := s.authorizeTOTPEnrollment(ctx, req.MfaChallengeToken)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateConfirmTOTPRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := s.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+
+	if user.IsTotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is already enabled")
+	}
+
+	if user.TotpSecret == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp enrollment has not been started")
+	}
+
+	secret, err := s.totpCipher.Decrypt(user.Username, user.TotpSecret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decrypt totp secret: %v", err)
+	}
+
+	step, ok := totp.Validate(req.GetCode(), secret, time.Now(), user.TotpLastStep)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "incorrect totp code")
+	}
+
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate recovery codes: %v", err)
+	}
+
+	hashedRecoveryCodes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashedCode, err := util.HashedPassword(code)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash recovery code: %v", err)
+		}
+
+		hashedRecoveryCodes = append(hashedRecoveryCodes, hashedCode)
+	}
+
+	_, err = s.store.ConfirmTOTPTx(ctx, &db.ConfirmTOTPTxParams{
+		Username:            user.Username,
+		HashedRecoveryCodes: hashedRecoveryCodes,
+		TotpLastStep:        step,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enable totp: %v", err)
+	}
+
+	response := &pb.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}
+
+	return response, nil
+}
+
+func validateConfirmTOTPRequest(req *pb.ConfirmTOTPRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateTOTPCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+
+	return violations
+}
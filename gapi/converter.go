@@ -9,10 +9,85 @@ import (
 
 func convertUser(user *db.User) *pb.User {
 	return &pb.User{
-		Username:          user.Username,
-		FullName:          user.FullName,
-		Email:             user.Email,
-		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
-		CreatedAt:         timestamppb.New(user.CreatedAt),
+		Username:               user.Username,
+		FullName:               user.FullName,
+		Email:                  user.Email,
+		PasswordChangedAt:      timestamppb.New(user.PasswordChangedAt),
+		CreatedAt:              timestamppb.New(user.CreatedAt),
+		MonthlyStatementOptOut: user.MonthlyStatementOptOut,
+		IsTotpEnabled:          user.IsTotpEnabled,
+		Role:                   user.Role,
+		IsEmailVerified:        user.IsEmailVerified,
+		IsDisabled:             user.IsDisabled,
 	}
 }
+
+func convertAccount(account *db.Account) *pb.Account {
+	return &pb.Account{
+		Id:             account.ID,
+		Owner:          account.Owner,
+		Balance:        account.Balance,
+		Currency:       account.Currency,
+		CreatedAt:      timestamppb.New(account.CreatedAt),
+		OverdraftLimit: account.OverdraftLimit,
+	}
+}
+
+func convertEntry(entry *db.Entry) *pb.Entry {
+	pbEntry := &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+
+	if entry.TransferID != nil {
+		pbEntry.TransferId = *entry.TransferID
+	}
+
+	return pbEntry
+}
+
+func convertAccountEntry(row *db.ListAccountEntriesRow) *pb.AccountEntry {
+	return &pb.AccountEntry{
+		Entry: convertEntry(&db.Entry{
+			ID:         row.ID,
+			AccountID:  row.AccountID,
+			Amount:     row.Amount,
+			CreatedAt:  row.CreatedAt,
+			TransferID: row.TransferID,
+		}),
+		RunningBalance:        row.RunningBalance,
+		CounterpartyAccountId: row.CounterpartyAccountID,
+	}
+}
+
+func convertTransfer(transfer *db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+	}
+}
+
+func convertSession(session *db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		CreatedAt: timestamppb.New(session.CreatedAt),
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+	}
+}
+
+func convertRolePermissions(rolePermissions []*db.RolePermission) []string {
+	permissions := make([]string, 0, len(rolePermissions))
+	for _, rolePermission := range rolePermissions {
+		permissions = append(permissions, rolePermission.Permission)
+	}
+
+	return permissions
+}
@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/validate"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	arg := db.CreateAccountParams{
+		Owner:    authPayload.Username,
+		Balance:  0,
+		Currency: req.GetCurrency(),
+	}
+
+	account, err := s.store.CreateAccount(ctx, &arg)
+	if err != nil {
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			return nil, status.Errorf(codes.AlreadyExists, "account already exists")
+		case db.ForeingKeyViolation:
+			return nil, status.Errorf(codes.PermissionDenied, "cannot create account")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to create account: %v", err)
+	}
+
+	response := &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}
+
+	return response, nil
+}
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	return violations
+}
@@ -52,7 +52,7 @@ func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb
 	txResult, err := s.store.CreateUserTx(ctx, &arg)
 	if err != nil {
 		if db.ErrorCode(err) == db.UniqueViolation {
-			return nil, status.Errorf(codes.AlreadyExists, "username already exists: %v", err)
+			return nil, status.Errorf(codes.AlreadyExists, "username already exists")
 		}
 
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/util"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := s.store.GetAccount(ctx, req.GetId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.DeleteAnyAccountPermission)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+	}
+
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	err = s.store.DeleteAccount(ctx, account.ID)
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeingKeyViolation {
+			return nil, status.Errorf(codes.FailedPrecondition, "account still has entries or transfers")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to delete account: %v", err)
+	}
+
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func validateDeleteAccountRequest(req *pb.DeleteAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) DisableUser(ctx context.Context, req *pb.DisableUserRequest) (*pb.DisableUserResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDisableUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.GetUsername() == authPayload.Username {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot disable yourself")
+	}
+
+	result, err := s.store.DisableUserTx(ctx, &db.DisableUserTxParams{
+		Username: req.GetUsername(),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to disable user: %v", err)
+	}
+
+	// reject the access tokens of the user right away on this instance
+	s.revocationChecker.Forget(req.GetUsername())
+
+	response := &pb.DisableUserResponse{
+		User: convertUser(result.User),
+	}
+
+	return response, nil
+}
+
+func validateDisableUserRequest(req *pb.DisableUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
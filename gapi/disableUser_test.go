@@ -0,0 +1,130 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestDisableUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		req           *pb.DisableUserRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.DisableUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.DisableUserRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := &db.DisableUserTxParams{
+					Username: user.Username,
+				}
+
+				disabledUser := *user
+				disabledUser.IsDisabled = true
+
+				store.EXPECT().DisableUserTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(&db.DisableUserTxResult{User: &disabledUser}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, res.GetUser().GetUsername())
+				require.True(t, res.GetUser().GetIsDisabled())
+			},
+		},
+		{
+			name: "DisableYourself",
+			req: &pb.DisableUserRequest{
+				Username: "banker",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DisableUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableUserResponse, err error) {
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.DisableUserRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DisableUserTx(gomock.Any(), gomock.Any()).Times(1).Return(&db.DisableUserTxResult{}, pgx.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableUserResponse, err error) {
+				requireStatusCode(t, codes.NotFound, err)
+			},
+		},
+		{
+			name: "SupportNotAllowed",
+			req: &pb.DisableUserRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DisableUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "support", util.SupportRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableUserResponse, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name: "InvalidUsername",
+			req: &pb.DisableUserRequest{
+				Username: "Invalid-User",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DisableUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DisableUserResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := callRPC(server, ctx, pb.SimpleBank_DisableUser_FullMethodName, tc.req, server.DisableUser)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
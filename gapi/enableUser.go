@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) EnableUser(ctx context.Context, req *pb.EnableUserRequest) (*pb.EnableUserResponse, error) {
+	violations := validateEnableUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := s.store.UpdateUserDisabled(ctx, &db.UpdateUserDisabledParams{
+		Username:   req.GetUsername(),
+		IsDisabled: false,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to enable user: %v", err)
+	}
+
+	s.revocationChecker.Forget(req.GetUsername())
+
+	response := &pb.EnableUserResponse{
+		User: convertUser(user),
+	}
+
+	return response, nil
+}
+
+func validateEnableUserRequest(req *pb.EnableUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/totp"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	authPayload, err := s.authorizeTOTPEnrollment(ctx, req.MfaChallengeToken)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	user, err := s.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+
+	if user.IsTotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate totp secret: %v", err)
+	}
+
+	encryptedSecret, err := s.totpCipher.Encrypt(user.Username, secret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encrypt totp secret: %v", err)
+	}
+
+	_, err = s.store.UpdateUserTOTPSecret(ctx, &db.UpdateUserTOTPSecretParams{
+		Username:   user.Username,
+		TotpSecret: encryptedSecret,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save totp secret: %v", err)
+	}
+
+	response := &pb.EnrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(totpIssuer, user.Username, secret),
+	}
+
+	return response, nil
+}
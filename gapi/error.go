@@ -1,9 +1,17 @@
 package gapi
 
 import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
@@ -28,3 +36,20 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
 }
+
+// tooManyRequestsError tells the client to wait before retrying. The wait is sent
+// as RetryInfo and as a retry-after header the gateway forwards to HTTP clients
+func tooManyRequestsError(ctx context.Context, retryAfter time.Duration) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.FormatInt(seconds, 10)))
+
+	retryInfo := &errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(seconds) * time.Second)}
+	statusExhausted := status.Newf(codes.ResourceExhausted, "too many requests, retry after %d seconds", seconds)
+
+	statusDetails, err := statusExhausted.WithDetails(retryInfo)
+	if err != nil {
+		return statusExhausted.Err()
+	}
+
+	return statusDetails.Err()
+}
@@ -0,0 +1,110 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/pb"
+	"main/statement"
+	"main/util"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ExportStatement(ctx context.Context, req *pb.ExportStatementRequest) (*httpbody.HttpBody, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateExportStatementRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := s.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.ReadAnyAccountPermission)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+	}
+
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	fromTime := req.GetFromTime().AsTime()
+	toTime := req.GetToTime().AsTime()
+
+	txResult, err := s.store.StatementTx(ctx, &db.StatementTxParams{
+		AccountID: account.ID,
+		FromTime:  fromTime,
+		ToTime:    toTime,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read statement: %v", err)
+	}
+	balances, entries := txResult.Balances, txResult.Entries
+
+	// transfers never change once created, so they can be read after the snapshot
+	transfers := []*db.Transfer{}
+	if transferIDs := statement.TransferIDs(entries); len(transferIDs) > 0 {
+		transfers, err = s.store.ListTransfersByIDs(ctx, transferIDs)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list statement transfers: %v", err)
+		}
+	}
+
+	stmt := statement.New(account, fromTime, toTime, balances, entries, transfers)
+
+	var buf bytes.Buffer
+	if err := stmt.Render(&buf, req.GetFormat()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to render statement: %v", err)
+	}
+
+	response := &httpbody.HttpBody{
+		ContentType: statement.ContentType(req.GetFormat()),
+		Data:        buf.Bytes(),
+	}
+
+	return response, nil
+}
+
+func validateExportStatementRequest(req *pb.ExportStatementRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.FromTime == nil {
+		violations = append(violations, fieldViolation("from_time", fmt.Errorf("must be provided")))
+	}
+
+	if req.ToTime == nil {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("must be provided")))
+	}
+
+	if req.FromTime != nil && req.ToTime != nil {
+		if err := validate.ValidateStatementPeriod(req.GetFromTime().AsTime(), req.GetToTime().AsTime()); err != nil {
+			violations = append(violations, fieldViolation("to_time", err))
+		}
+	}
+
+	if err := validate.ValidateStatementFormat(req.GetFormat()); err != nil {
+		violations = append(violations, fieldViolation("format", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,191 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"main/validate"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExportStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	fromTime := time.Now().Add(-time.Hour)
+	toTime := time.Now()
+
+	transfer := &db.Transfer{
+		ID:            util.RandomInt(1, 1000),
+		FromAccountID: account.ID,
+		ToAccountID:   account.ID + 1,
+		Amount:        10,
+		CreatedAt:     fromTime.Add(time.Minute),
+	}
+
+	entries := []*db.Entry{
+		{
+			ID:         1,
+			AccountID:  account.ID,
+			Amount:     -10,
+			CreatedAt:  transfer.CreatedAt,
+			TransferID: &transfer.ID,
+		},
+	}
+
+	balances := &db.GetAccountStatementBalancesRow{
+		OpeningBalance: account.Balance + 10,
+		ClosingBalance: account.Balance,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ExportStatementRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *httpbody.HttpBody, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ExportStatementRequest{
+				AccountId: account.ID,
+				FromTime:  timestamppb.New(fromTime),
+				ToTime:    timestamppb.New(toTime),
+				Format:    util.CSVFormat,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := &db.StatementTxParams{
+					AccountID: account.ID,
+					FromTime:  timestamppb.New(fromTime).AsTime(),
+					ToTime:    timestamppb.New(toTime).AsTime(),
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().StatementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(&db.StatementTxResult{Balances: balances, Entries: entries}, nil)
+				store.EXPECT().ListTransfersByIDs(gomock.Any(), gomock.Eq([]int64{transfer.ID})).Times(1).Return([]*db.Transfer{transfer}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, "text/csv", res.GetContentType())
+
+				lines := strings.Split(strings.TrimSpace(string(res.GetData())), "\n")
+				require.Len(t, lines, len(entries)+3)
+			},
+		},
+		{
+			name: "UnsupportedFormat",
+			req: &pb.ExportStatementRequest{
+				AccountId: account.ID,
+				FromTime:  timestamppb.New(fromTime),
+				ToTime:    timestamppb.New(toTime),
+				Format:    "pdf",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "MissingPeriod",
+			req: &pb.ExportStatementRequest{
+				AccountId: account.ID,
+				Format:    util.OFXFormat,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "PeriodTooLong",
+			req: &pb.ExportStatementRequest{
+				AccountId: account.ID,
+				FromTime:  timestamppb.New(fromTime),
+				ToTime:    timestamppb.New(fromTime.Add(validate.MaxStatementPeriod + time.Hour)),
+				Format:    util.CSVFormat,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			req: &pb.ExportStatementRequest{
+				AccountId: account.ID,
+				FromTime:  timestamppb.New(fromTime),
+				ToTime:    timestamppb.New(toTime),
+				Format:    util.CAMT053Format,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().StatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "unauthorized_user", util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *httpbody.HttpBody, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := callRPC(server, ctx, pb.SimpleBank_ExportStatement_FullMethodName, tc.req, server.ExportStatement)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package gapi
+
+import (
+	"encoding/json"
+	"log/slog"
+	"main/token"
+	"net/http"
+)
+
+// JWKSHandler serves the public keys that verify access tokens as a JSON Web Key Set,
+// so other services can validate tokens without holding the signing secret
+func (s *Server) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		publisher, ok := s.tokenMaker.(token.KeySetPublisher)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+
+		err := json.NewEncoder(w).Encode(publisher.KeySet())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to write jwks", slog.String("error", err.Error()))
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"main/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	signingKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	testCases := []struct {
+		name          string
+		setupMaker    func(t *testing.T, server *Server)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupMaker: func(t *testing.T, server *Server) {
+				maker, err := token.NewAsymmetricJWTMaker(token.EdDSA, "key-1", signingKeyPEM, nil)
+				require.NoError(t, err)
+				server.tokenMaker = maker
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var keySet token.JSONWebKeySet
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &keySet))
+				require.Len(t, keySet.Keys, 1)
+				require.Equal(t, "key-1", keySet.Keys[0].KeyID)
+				require.Equal(t, "OKP", keySet.Keys[0].KeyType)
+				require.Equal(t, token.EdDSA, keySet.Keys[0].Algorithm)
+			},
+		},
+		{
+			name:       "SymmetricTokens",
+			setupMaker: func(t *testing.T, server *Server) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, nil, nil)
+			tc.setupMaker(t, server)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+			server.JWKSHandler().ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
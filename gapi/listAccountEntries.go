@@ -0,0 +1,123 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/pagination"
+	"main/pb"
+	"main/util"
+	"main/validate"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListAccountEntries(ctx context.Context, req *pb.ListAccountEntriesRequest) (*pb.ListAccountEntriesResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountEntriesRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := s.store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, account.Owner, util.ReadAnyAccountPermission)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+	}
+
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	// the page token is only valid for the account and filters that issued it
+	requestParams := []any{account.ID, req.FromTime, req.ToTime, req.Direction}
+
+	cursor, err := s.parsePageToken(req.GetPageToken(), requestParams...)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// fetch one extra row to find out if there is a next page
+	arg := db.ListAccountEntriesParams{
+		AccountID:       account.ID,
+		FromTime:        time.Time{},
+		ToTime:          time.Now(),
+		Direction:       req.Direction,
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorID:        cursor.ID,
+		PageLimit:       pageSize + 1,
+	}
+
+	if req.FromTime != nil {
+		arg.FromTime = req.GetFromTime().AsTime()
+	}
+
+	if req.ToTime != nil {
+		arg.ToTime = req.GetToTime().AsTime()
+	}
+
+	entries, err := s.store.ListAccountEntries(ctx, &arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account entries: %v", err)
+	}
+
+	response := &pb.ListAccountEntriesResponse{}
+
+	if len(entries) > int(pageSize) {
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+
+		response.NextPageToken, err = s.createNextPageToken(&pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, requestParams...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response.Entries = make([]*pb.AccountEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, convertAccountEntry(entry))
+	}
+
+	return response, nil
+}
+
+func validateListAccountEntriesRequest(req *pb.ListAccountEntriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+
+	if req.FromTime != nil && req.ToTime != nil && !req.GetFromTime().AsTime().Before(req.GetToTime().AsTime()) {
+		violations = append(violations, fieldViolation("to_time", fmt.Errorf("must be after from_time")))
+	}
+
+	if req.Direction != nil {
+		if err := validate.ValidateDirection(req.GetDirection()); err != nil {
+			violations = append(violations, fieldViolation("direction", err))
+		}
+	}
+
+	if err := validate.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
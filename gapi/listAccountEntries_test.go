@@ -0,0 +1,216 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAccountEntriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	transferID := util.RandomInt(1, 1000)
+	rows := []*db.ListAccountEntriesRow{
+		{
+			ID:                    1,
+			AccountID:             account.ID,
+			Amount:                -10,
+			TransferID:            &transferID,
+			RunningBalance:        account.Balance - 10,
+			CounterpartyAccountID: account.ID + 1,
+		},
+	}
+
+	fromTime := time.Now().Add(-time.Hour)
+	toTime := time.Now()
+	debit := util.DebitDirection
+	invalidDirection := "sideways"
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListAccountEntriesRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListAccountEntriesResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListAccountEntriesRequest{
+				AccountId: account.ID,
+				FromTime:  timestamppb.New(fromTime),
+				ToTime:    timestamppb.New(toTime),
+				Direction: &debit,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := &db.ListAccountEntriesParams{
+					AccountID: account.ID,
+					FromTime:  timestamppb.New(fromTime).AsTime(),
+					ToTime:    timestamppb.New(toTime).AsTime(),
+					Direction: &debit,
+					PageLimit: 6,
+				}
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountEntriesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), 1)
+				entry := res.GetEntries()[0]
+				require.Equal(t, int64(-10), entry.GetEntry().GetAmount())
+				require.Equal(t, transferID, entry.GetEntry().GetTransferId())
+				require.Equal(t, account.Balance-10, entry.GetRunningBalance())
+				require.Equal(t, account.ID+1, entry.GetCounterpartyAccountId())
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			req: &pb.ListAccountEntriesRequest{
+				AccountId: account.ID,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "unauthorized_user", util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "InvalidDirection",
+			req: &pb.ListAccountEntriesRequest{
+				AccountId: account.ID,
+				Direction: &invalidDirection,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidTimeRange",
+			req: &pb.ListAccountEntriesRequest{
+				AccountId: account.ID,
+				FromTime:  timestamppb.New(toTime),
+				ToTime:    timestamppb.New(fromTime),
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.ListAccountEntriesRequest{
+				AccountId: account.ID,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountEntriesResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := callRPC(server, ctx, pb.SimpleBank_ListAccountEntries_FullMethodName, tc.req, server.ListAccountEntries)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestListAccountEntriesPageTokenMismatch(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	rows := []*db.ListAccountEntriesRow{
+		{ID: 2, AccountID: account.ID, Amount: -10},
+		{ID: 1, AccountID: account.ID, Amount: -20},
+	}
+
+	storeCtrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(storeCtrl)
+	server := newTestServer(t, store, nil)
+
+	debit := util.DebitDirection
+	credit := util.CreditDirection
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+	store.EXPECT().ListAccountEntries(gomock.Any(), gomock.Any()).Times(1).Return(rows, nil)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, user.Role, time.Minute)
+	req := &pb.ListAccountEntriesRequest{AccountId: account.ID, Direction: &debit, PageSize: 1}
+	res, err := callRPC(server, ctx, pb.SimpleBank_ListAccountEntries_FullMethodName, req, server.ListAccountEntries)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetNextPageToken())
+
+	// the token was issued for debits, it cannot page through credits
+	req = &pb.ListAccountEntriesRequest{AccountId: account.ID, Direction: &credit, PageSize: 1, PageToken: res.GetNextPageToken()}
+	_, err = callRPC(server, ctx, pb.SimpleBank_ListAccountEntries_FullMethodName, req, server.ListAccountEntries)
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
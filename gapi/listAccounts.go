@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pagination"
+	"main/pb"
+	"main/validate"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the page token is only valid for the owner that issued it
+	cursor, err := s.parsePageToken(req.GetPageToken(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// fetch one extra row to find out if there is a next page
+	arg := db.ListAccountsParams{
+		Owner:           authPayload.Username,
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorID:        cursor.ID,
+		PageLimit:       pageSize + 1,
+	}
+
+	accounts, err := s.store.ListAccounts(ctx, &arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %v", err)
+	}
+
+	response := &pb.ListAccountsResponse{}
+
+	if len(accounts) > int(pageSize) {
+		accounts = accounts[:pageSize]
+		last := accounts[len(accounts)-1]
+
+		response.NextPageToken, err = s.createNextPageToken(&pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, authPayload.Username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response.Accounts = make([]*pb.Account, 0, len(accounts))
+
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, convertAccount(account))
+	}
+
+	return response, nil
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"context"
+	"main/pb"
+	"main/validate"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListRolePermissions(ctx context.Context, req *pb.ListRolePermissionsRequest) (*pb.ListRolePermissionsResponse, error) {
+	violations := validateListRolePermissionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rolePermissions, err := s.store.ListRolePermissions(ctx, req.GetRole())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list role permissions: %v", err)
+	}
+
+	response := &pb.ListRolePermissionsResponse{
+		Role:        req.GetRole(),
+		Permissions: convertRolePermissions(rolePermissions),
+	}
+
+	return response, nil
+}
+
+func validateListRolePermissionsRequest(req *pb.ListRolePermissionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
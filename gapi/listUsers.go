@@ -0,0 +1,88 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pagination"
+	"main/pb"
+	"main/validate"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	violations := validateListUsersRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// the page token is only valid for the filters that issued it
+	requestParams := []any{req.GetSearch(), req.Role, req.IsEmailVerified}
+
+	cursor, err := s.parsePageToken(req.GetPageToken(), requestParams...)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// fetch one extra row to find out if there is a next page
+	arg := &db.ListUsersParams{
+		Role:            req.Role,
+		IsEmailVerified: req.IsEmailVerified,
+		CursorCreatedAt: cursor.CreatedAt,
+		CursorUsername:  cursor.Key,
+		PageLimit:       pageSize + 1,
+	}
+
+	if req.GetSearch() != "" {
+		arg.Search = &req.Search
+	}
+
+	users, err := s.store.ListUsers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+
+	response := &pb.ListUsersResponse{}
+
+	if len(users) > int(pageSize) {
+		users = users[:pageSize]
+		last := users[len(users)-1]
+
+		response.NextPageToken, err = s.createNextPageToken(&pagination.Cursor{CreatedAt: last.CreatedAt, Key: last.Username}, requestParams...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response.Users = make([]*pb.User, 0, len(users))
+
+	for _, user := range users {
+		response.Users = append(response.Users, convertUser(user))
+	}
+
+	return response, nil
+}
+
+func validateListUsersRequest(req *pb.ListUsersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetSearch() != "" {
+		if err := validate.ValidateSearch(req.GetSearch()); err != nil {
+			violations = append(violations, fieldViolation("search", err))
+		}
+	}
+
+	if req.Role != nil {
+		if err := validate.ValidateRole(req.GetRole()); err != nil {
+			violations = append(violations, fieldViolation("role", err))
+		}
+	}
+
+	if err := validate.ValidatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,152 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListUsersAPI(t *testing.T) {
+	users := make([]*db.User, 3)
+	for i := range users {
+		users[i], _ = randomUser(t)
+		users[i].CreatedAt = time.Now().Add(time.Duration(i) * time.Second)
+	}
+
+	search := "smith"
+	role := util.DepositorRole
+	verified := true
+	invalidRole := "auditor"
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListUsersRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListUsersResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListUsersRequest{
+				Search:          search,
+				Role:            &role,
+				IsEmailVerified: &verified,
+				PageSize:        2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := &db.ListUsersParams{
+					Search:          &search,
+					Role:            &role,
+					IsEmailVerified: &verified,
+					PageLimit:       3,
+				}
+
+				store.EXPECT().ListUsers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(users, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetUsers(), 2)
+				require.Equal(t, users[0].Username, res.GetUsers()[0].GetUsername())
+				require.Equal(t, role, res.GetUsers()[0].GetRole())
+				require.NotEmpty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			name: "InvalidRole",
+			req: &pb.ListUsersRequest{
+				Role: &invalidRole,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req:  &pb.ListUsersRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "depositor", util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListUsersResponse, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := callRPC(server, ctx, pb.SimpleBank_ListUsers_FullMethodName, tc.req, server.ListUsers)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestListUsersNextPage(t *testing.T) {
+	user, _ := randomUser(t)
+	user.CreatedAt = time.Now()
+
+	storeCtrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(storeCtrl)
+	server := newTestServer(t, store, nil)
+
+	store.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(1).Return([]*db.User{user, user}, nil)
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, "banker", util.BankerRole, time.Minute)
+	res, err := callRPC(server, ctx, pb.SimpleBank_ListUsers_FullMethodName, &pb.ListUsersRequest{PageSize: 1}, server.ListUsers)
+	require.NoError(t, err)
+
+	// the next page starts after the username of the last user
+	arg := &db.ListUsersParams{
+		CursorCreatedAt: user.CreatedAt,
+		CursorUsername:  user.Username,
+		PageLimit:       2,
+	}
+	store.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(ctx context.Context, got *db.ListUsersParams) ([]*db.User, error) {
+			require.True(t, arg.CursorCreatedAt.Equal(got.CursorCreatedAt))
+			require.Equal(t, arg.CursorUsername, got.CursorUsername)
+			require.Equal(t, arg.PageLimit, got.PageLimit)
+			return []*db.User{}, nil
+		})
+
+	_, err = callRPC(server, ctx, pb.SimpleBank_ListUsers_FullMethodName, &pb.ListUsersRequest{PageSize: 1, PageToken: res.GetNextPageToken()}, server.ListUsers)
+	require.NoError(t, err)
+
+	// the token was issued without a search, it cannot page through a search
+	req := &pb.ListUsersRequest{Search: user.Username, PageSize: 1, PageToken: res.GetNextPageToken()}
+	_, err = callRPC(server, ctx, pb.SimpleBank_ListUsers_FullMethodName, req, server.ListUsers)
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+}
@@ -2,8 +2,8 @@ package gapi
 
 import (
 	"context"
+	"main/logging"
 	"net/http"
-	"os"
 	"time"
 
 	"log/slog"
@@ -31,20 +31,13 @@ func GrpcLogger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handle
 		slog.String("status_text", statusCode.String()),
 	}
 
-	var logger *slog.Logger
-
-	if os.Getenv("ENVIRONMENT") == "dev" {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-	} else {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-	}
-
+	level := slog.LevelInfo
 	if int(statusCode) != 0 {
-		logger.Error("received a gRPC request")
-	} else {
-		logger.Info("received a gRPC request")
+		level = slog.LevelError
 	}
 
+	slog.LogAttrs(ctx, level, "received a gRPC request", slogAttrs...)
+
 	return
 }
 
@@ -83,18 +76,11 @@ func HttpLogger(handler http.Handler) http.Handler {
 			slog.String("status_text", http.StatusText(rr.StatusCode)),
 		}
 
-		var logger *slog.Logger
-
-		if os.Getenv("ENVIRONMENT") == "dev" {
-			logger = slog.New(slog.NewTextHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-		} else {
-			logger = slog.New(slog.NewJSONHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-		}
-
 		if rr.StatusCode != http.StatusOK {
-			logger.Error("received a HTTP request", slog.String("body", string(rr.Body)))
+			slogAttrs = append(slogAttrs, slog.String("body", logging.RedactJSON(rr.Body)))
+			slog.LogAttrs(r.Context(), slog.LevelError, "received a HTTP request", slogAttrs...)
 		} else {
-			logger.Info("received a HTTP request")
+			slog.LogAttrs(r.Context(), slog.LevelInfo, "received a HTTP request", slogAttrs...)
 		}
 	})
 }
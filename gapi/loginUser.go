@@ -2,7 +2,6 @@ package gapi
 
 import (
 	"context"
-	"main/database/db"
 	"main/pb"
 	"main/util"
 	"main/validate"
@@ -11,7 +10,6 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
@@ -20,10 +18,19 @@ func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.L
 		return nil, invalidArgumentError(violations)
 	}
 
+	clientIP := s.extractMetaData(ctx).ClientIP
+	if err := s.checkLoginAllowed(ctx, req.GetUsername(), clientIP); err != nil {
+		return nil, err
+	}
+
 	user, err := s.store.GetUser(ctx, req.GetUsername())
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+			if err := s.recordLoginFailure(ctx, clientIP); err != nil {
+				return nil, err
+			}
+
+			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
 
 		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
@@ -31,44 +38,52 @@ func (s *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.L
 
 	err = util.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "incorrect password: %v", err)
+		if err := s.recordLoginFailure(ctx, clientIP); err != nil {
+			return nil, err
+		}
+
+		return nil, status.Errorf(codes.NotFound, "incorrect password")
 	}
 
-	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.TokenDuration)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create access token: %v", err)
+	if user.IsDisabled {
+		return nil, status.Errorf(codes.PermissionDenied, "user is disabled")
+	}
+
+	// failures are only forgotten once the second factor was verified as well
+	if user.IsTotpEnabled || user.Role != util.DepositorRole {
+		return s.createMFAChallenge(user)
 	}
 
-	refreshToken, refreshPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.RefreshDuration)
+	err = s.loginGuard.RecordSuccess(ctx, user.Username)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create access token: %v", err)
+		return nil, status.Errorf(codes.Internal, "%v", err)
 	}
 
-	metaData := s.extractMetaData(ctx)
-
-	session, err := s.store.CreateSession(ctx, &db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    metaData.UserAgent,
-		ClientIp:     metaData.ClientIP,
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiredAt,
-	})
+	return s.createLoginResponse(ctx, user)
+}
+
+// checkLoginAllowed counts the login attempt and rejects it while the user or client ip
+// is backed off or locked out
+func (s *Server) checkLoginAllowed(ctx context.Context, username string, clientIP string) error {
+	retryAfter, err := s.loginGuard.Attempt(ctx, username, clientIP)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create session: %v", err)
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if retryAfter > 0 {
+		return tooManyRequestsError(ctx, retryAfter)
 	}
 
-	response := &pb.LoginUserResponse{
-		User:                  convertUser(user),
-		SessionId:             session.ID.String(),
-		AccessToken:           accessToken,
-		RefreshToken:          refreshToken,
-		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
-		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
+	return nil
+}
+
+func (s *Server) recordLoginFailure(ctx context.Context, clientIP string) error {
+	err := s.loginGuard.RecordFailure(ctx, clientIP)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
 	}
 
-	return response, nil
+	return nil
 }
 
 func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"main/database/mockdb"
+	"main/pb"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserLockout(t *testing.T) {
+	user, password := randomUser(t)
+
+	storeCtrl := gomock.NewController(t)
+	defer storeCtrl.Finish()
+	store := mockdb.NewMockStore(storeCtrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(testLoginPolicy.LockoutAfter).Return(user, nil)
+	store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store, nil)
+
+	for i := 0; i < testLoginPolicy.LockoutAfter; i++ {
+		_, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+			Username: user.Username,
+			Password: "incorrect",
+		})
+		requireStatusCode(t, codes.NotFound, err)
+	}
+
+	// even the correct password is rejected while the user is locked out
+	_, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+		Username: user.Username,
+		Password: password,
+	})
+	requireStatusCode(t, codes.ResourceExhausted, err)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.Equal(t, time.Minute, retryInfo.GetRetryDelay().AsDuration())
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/pb"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest) (*pb.LogoutUserResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateLogoutUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := s.refreshTokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	session, err := s.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+
+	if session.Username != authPayload.Username || session.RefreshToken != req.GetRefreshToken() {
+		return nil, status.Errorf(codes.PermissionDenied, "session doesn't belong to the authenticated user")
+	}
+
+	// block the whole family, so the live descendant of a rotated session stops working too
+	_, err = s.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to block session: %v", err)
+	}
+
+	return &pb.LogoutUserResponse{}, nil
+}
+
+func validateLogoutUserRequest(req *pb.LogoutUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetRefreshToken() == "" {
+		violations = append(violations, fieldViolation("refresh_token", fmt.Errorf("must be provided")))
+	}
+
+	return violations
+}
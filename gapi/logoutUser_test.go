@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogoutUserAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore, session *db.Session)
+		checkResponse func(t *testing.T, res *pb.LogoutUserResponse, err error)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore, session *db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).Times(1).Return(int64(2), nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			name:     "OtherUserSession",
+			username: "other_user",
+			buildStubs: func(store *mockdb.MockStore, session *db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			server := newTestServer(t, store, nil)
+
+			refreshToken, refreshPayload, err := server.refreshTokenMaker.CreateToken(user.Username, user.Role, time.Hour)
+			require.NoError(t, err)
+
+			session := &db.Session{
+				ID:           refreshPayload.ID,
+				Username:     user.Username,
+				RefreshToken: refreshToken,
+				ExpiresAt:    refreshPayload.ExpiredAt,
+				FamilyID:     uuid.New(),
+			}
+			tc.buildStubs(store, session)
+
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, util.DepositorRole, time.Minute)
+
+			res, err := callRPC(server, ctx, pb.SimpleBank_LogoutUser_FullMethodName, &pb.LogoutUserRequest{RefreshToken: refreshToken}, server.LogoutUser)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
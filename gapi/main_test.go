@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"main/database/db"
+	"main/lockout"
+	"main/permission"
+	"main/revocation"
 	"main/token"
 	"main/util"
 	"main/worker"
@@ -11,9 +14,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+// testLoginPolicy locks a user out after a few failed logins
+var testLoginPolicy = lockout.Policy{LockoutAfter: 3, LockoutDuration: time.Minute}
+
 func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDistributor) *Server {
 	config := &util.ConfigDatabase{
 		SecretKey:     util.RandomString(32),
@@ -23,9 +30,42 @@ func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDist
 	server, err := NewServer(store, config, taskDistributor)
 	require.NoError(t, err)
 
+	// keep the password change lookups out of the store expectations of every test
+	server.revocationChecker = revocation.NewChecker(unchangedPasswordUsers{}, time.Minute)
+	server.permissionChecker = permission.NewChecker(seededRolePermissions{}, time.Minute)
+	server.loginGuard = lockout.NewGuard(lockout.NewMemoryStore(), testLoginPolicy, lockout.Policy{})
+
 	return server
 }
 
+// unchangedPasswordUsers returns users that never changed their password
+type unchangedPasswordUsers struct{}
+
+func (unchangedPasswordUsers) GetUser(ctx context.Context, username string) (*db.User, error) {
+	return &db.User{Username: username}, nil
+}
+
+// seededRolePermissions grants the permissions the migrations seed the roles with
+type seededRolePermissions struct{}
+
+func (seededRolePermissions) ListRolePermissions(ctx context.Context, role string) ([]*db.RolePermission, error) {
+	seeded := map[string][]string{
+		util.DepositorRole: {util.CreateAccountPermission, util.CreateTransferPermission},
+		util.BankerRole: {
+			util.CreateAccountPermission, util.ReadAnyAccountPermission, util.DeleteAnyAccountPermission,
+			util.CreateTransferPermission, util.ManageUsersPermission, util.ManageRolesPermission,
+		},
+		util.SupportRole: {util.ReadAnyAccountPermission},
+	}
+
+	rolePermissions := []*db.RolePermission{}
+	for _, permission := range seeded[role] {
+		rolePermissions = append(rolePermissions, &db.RolePermission{Role: role, Permission: permission})
+	}
+
+	return rolePermissions, nil
+}
+
 func randomUser(t *testing.T) (*db.User, string) {
 	password := util.RandomString(6)
 	hashedPassword, err := util.HashedPassword(password)
@@ -53,3 +93,28 @@ func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username st
 	}
 	return metadata.NewIncomingContext(context.Background(), md)
 }
+
+func randomAccount(owner string) *db.Account {
+	return &db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    owner,
+		Balance:  util.RandomMoney(),
+		Currency: util.RandomCurrency(),
+	}
+}
+
+// callRPC calls the handler behind the auth interceptor, as the gRPC server does
+func callRPC[Req any, Res any](server *Server, ctx context.Context, method string, req Req, rpc func(context.Context, Req) (Res, error)) (Res, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return rpc(ctx, req.(Req))
+	}
+
+	var empty Res
+	res, err := server.AuthUnaryInterceptor(ctx, req, info, handler)
+	if err != nil {
+		return empty, err
+	}
+
+	return res.(Res), nil
+}
@@ -2,7 +2,11 @@ package gapi
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
 
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -11,6 +15,8 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	grpcUserAgentHeader        = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	retryAfterHeader           = "retry-after"
+	idempotencyKeyHeader       = "idempotency-key"
 )
 
 type Metadata struct {
@@ -20,26 +26,126 @@ type Metadata struct {
 
 func (s *Server) extractMetaData(ctx context.Context) *Metadata {
 	metaData := &Metadata{}
+	var forwardedFor []string
+
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		userAgents := md.Get(grpcGatewayUserAgentHeader)
+		userAgents := md.Get(grpcUserAgentHeader)
 		if len(userAgents) > 0 {
 			metaData.UserAgent = userAgents[0]
 		}
 
-		userAgents = md.Get(grpcUserAgentHeader)
+		// requests proxied by the gateway carry the user agent of the HTTP client
+		userAgents = md.Get(grpcGatewayUserAgentHeader)
 		if len(userAgents) > 0 {
 			metaData.UserAgent = userAgents[0]
 		}
 
-		clientIPs := md.Get(xForwardedForHeader)
-		if len(clientIPs) > 0 {
-			metaData.ClientIP = clientIPs[0]
-		}
+		forwardedFor = md.Get(xForwardedForHeader)
 	}
 
 	if p, ok := peer.FromContext(ctx); ok {
-		metaData.ClientIP = p.Addr.String()
+		metaData.ClientIP = s.clientIP(addrHost(p.Addr), forwardedFor)
 	}
 
 	return metaData
 }
+
+// clientIP returns the address of the client behind the trusted proxies. Every proxy
+// appends the address it was called from to X-Forwarded-For, so the header is read
+// from the right for as long as the address that appended the entry is trusted.
+// Anything further left was sent by the client and cannot be trusted
+func (s *Server) clientIP(remoteHost string, forwardedFor []string) string {
+	addresses := []string{}
+	for _, value := range forwardedFor {
+		addresses = append(addresses, strings.Split(value, ",")...)
+	}
+
+	clientIP := remoteHost
+	for i := len(addresses) - 1; i >= 0 && s.isTrustedProxy(clientIP); i-- {
+		address := strings.TrimSpace(addresses[i])
+		if net.ParseIP(address) == nil {
+			break
+		}
+
+		clientIP = address
+	}
+
+	return clientIP
+}
+
+// isTrustedProxy reports whether the address may tell us the client address: the
+// gateway running next to the gRPC server, or one of the TRUSTED_PROXIES
+func (s *Server) isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	if ip.IsLoopback() {
+		return true
+	}
+
+	for _, network := range s.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseTrustedProxies parses the addresses and CIDRs of TRUSTED_PROXIES
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// addrHost returns the address without its port, like the HTTP server reports client IPs
+func addrHost(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+
+	return host
+}
+
+// GatewayIncomingHeaderMatcher forwards the Idempotency-Key header of HTTP clients
+// to gRPC as metadata, next to the headers the gateway forwards by default
+func GatewayIncomingHeaderMatcher(key string) (string, bool) {
+	if strings.ToLower(key) == idempotencyKeyHeader {
+		return idempotencyKeyHeader, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
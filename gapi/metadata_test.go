@@ -0,0 +1,105 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGatewayIncomingHeaderMatcher(t *testing.T) {
+	key, ok := GatewayIncomingHeaderMatcher("Idempotency-Key")
+	require.True(t, ok)
+	require.Equal(t, idempotencyKeyHeader, key)
+
+	// the headers the gateway forwards by default are kept
+	key, ok = GatewayIncomingHeaderMatcher("Authorization")
+	require.True(t, ok)
+	require.Equal(t, runtime.MetadataPrefix+"Authorization", key)
+
+	_, ok = GatewayIncomingHeaderMatcher("X-Custom")
+	require.False(t, ok)
+}
+
+func TestExtractMetaDataClientIP(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	require.NoError(t, err)
+	server.trustedProxies = trustedProxies
+
+	newContext := func(peerIP string, forwardedFor string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 50000}})
+		if forwardedFor != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(xForwardedForHeader, forwardedFor))
+		}
+
+		return ctx
+	}
+
+	testCases := []struct {
+		name         string
+		peerIP       string
+		forwardedFor string
+		clientIP     string
+	}{
+		{
+			name:     "NoProxy",
+			peerIP:   "198.51.100.1",
+			clientIP: "198.51.100.1",
+		},
+		{
+			name:         "UntrustedPeer",
+			peerIP:       "198.51.100.1",
+			forwardedFor: "203.0.113.9",
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "Gateway",
+			peerIP:       "127.0.0.1",
+			forwardedFor: "203.0.113.9, 198.51.100.1",
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "GatewayBehindTrustedLoadBalancer",
+			peerIP:       "127.0.0.1",
+			forwardedFor: "203.0.113.9, 198.51.100.1, 10.1.2.3",
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "TrustedPeer",
+			peerIP:       "192.0.2.1",
+			forwardedFor: "198.51.100.1",
+			clientIP:     "198.51.100.1",
+		},
+		{
+			name:         "InvalidForwardedAddress",
+			peerIP:       "10.1.2.3",
+			forwardedFor: "unknown",
+			clientIP:     "10.1.2.3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metaData := server.extractMetaData(newContext(tc.peerIP, tc.forwardedFor))
+			require.Equal(t, tc.clientIP, metaData.ClientIP)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := parseTrustedProxies([]string{"not-an-ip"})
+	require.Error(t, err)
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	networks, err := parseTrustedProxies(nil)
+	require.NoError(t, err)
+	require.Empty(t, networks)
+}
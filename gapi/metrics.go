@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"context"
+	"main/metrics"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcMetrics records the count and latency of unary calls per method and status code
+func GrpcMetrics(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	startTime := time.Now()
+	resp, err := handler(ctx, req)
+	metrics.ObserveGrpcRequest(info.FullMethod, status.Code(err), time.Since(startTime))
+
+	return resp, err
+}
+
+// GrpcStreamMetrics records the count and latency of streaming calls per method and status code
+func GrpcStreamMetrics(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	startTime := time.Now()
+	err := handler(srv, stream)
+	metrics.ObserveGrpcRequest(info.FullMethod, status.Code(err), time.Since(startTime))
+
+	return err
+}
+
+// HttpMetrics records the count and latency of HTTP requests per method and status code
+func HttpMetrics(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		rr := &ResponseRecorder{
+			ResponseWriter: w,
+			StatusCode:     http.StatusOK,
+		}
+		handler.ServeHTTP(rr, r)
+		metrics.ObserveHttpRequest(r.Method, rr.StatusCode, time.Since(startTime))
+	})
+}
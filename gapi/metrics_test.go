@@ -0,0 +1,42 @@
+package gapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpMetricsJunkMethod(t *testing.T) {
+	handler := HttpMetrics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+
+	request := httptest.NewRequest("JUNKMETHOD", "/v1/accounts", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	methods := map[string]bool{}
+	for _, family := range families {
+		if family.GetName() != "simplebank_http_requests_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "method" {
+					methods[label.GetValue()] = true
+				}
+			}
+		}
+	}
+
+	require.True(t, methods["OTHER"])
+	require.False(t, methods["JUNKMETHOD"])
+}
@@ -0,0 +1,92 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/pb"
+	"main/token"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	totpIssuer           = "Simple Bank"
+	mfaChallengeDuration = 5 * time.Minute
+)
+
+// createLoginResponse creates the access and refresh tokens and the session of a completed login
+func (s *Server) createLoginResponse(ctx context.Context, user *db.User) (*pb.LoginUserResponse, error) {
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(user.Username, user.Role, s.config.TokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token: %v", err)
+	}
+
+	refreshToken, refreshPayload, err := s.refreshTokenMaker.CreateToken(user.Username, user.Role, s.config.RefreshDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token: %v", err)
+	}
+
+	metaData := s.extractMetaData(ctx)
+
+	session, err := s.store.CreateSession(ctx, &db.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		UserAgent:    metaData.UserAgent,
+		ClientIp:     metaData.ClientIP,
+		IsBlocked:    false,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+		FamilyID:     refreshPayload.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create session: %v", err)
+	}
+
+	response := &pb.LoginUserResponse{
+		User:                  convertUser(user),
+		SessionId:             session.ID.String(),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
+	}
+
+	return response, nil
+}
+
+// createMFAChallenge returns the short-lived challenge token that VerifyMFA exchanges for
+// access and refresh tokens. Staff without TOTP must enrol with it first
+func (s *Server) createMFAChallenge(user *db.User) (*pb.LoginUserResponse, error) {
+	challengeToken, _, err := s.mfaTokenMaker.CreateToken(user.Username, user.Role, mfaChallengeDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create mfa challenge token: %v", err)
+	}
+
+	response := &pb.LoginUserResponse{
+		User:                  convertUser(user),
+		MfaRequired:           user.IsTotpEnabled,
+		MfaEnrollmentRequired: !user.IsTotpEnabled,
+		MfaChallengeToken:     challengeToken,
+	}
+
+	return response, nil
+}
+
+// authorizeTOTPEnrollment accepts either an MFA challenge token, for users that must enrol
+// before they can log in, or the bearer access token of a logged in user
+func (s *Server) authorizeTOTPEnrollment(ctx context.Context, challengeToken *string) (*token.Payload, error) {
+	if challengeToken == nil {
+		return authPayloadFromContext(ctx)
+	}
+
+	payload, err := s.mfaTokenMaker.VerifyToken(*challengeToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mfa challenge token: %s", err)
+	}
+
+	return payload, nil
+}
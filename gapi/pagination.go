@@ -0,0 +1,29 @@
+package gapi
+
+import (
+	"main/pagination"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parsePageToken returns the cursor of a page token issued for the same requestParams
+func (s *Server) parsePageToken(pageToken string, requestParams ...any) (*pagination.Cursor, error) {
+	cursor, err := s.pageTokenMaker.VerifyToken(pageToken, requestParams...)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("page_token", err)})
+	}
+
+	return cursor, nil
+}
+
+// createNextPageToken binds the cursor of the next page to the requestParams
+func (s *Server) createNextPageToken(cursor *pagination.Cursor, requestParams ...any) (string, error) {
+	nextPageToken, err := s.pageTokenMaker.CreateToken(cursor, requestParams...)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to create page token: %v", err)
+	}
+
+	return nextPageToken, nil
+}
@@ -0,0 +1,79 @@
+package gapi
+
+import (
+	"main/pb"
+	"main/util"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+type accessLevel int
+
+const (
+	// accessDenied is the zero value, so a policy without access level denies the call
+	accessDenied accessLevel = iota
+	// accessPublic methods are served without looking at the access token
+	accessPublic
+	// accessOptional methods verify the access token only when one is sent
+	accessOptional
+	// accessAuthenticated methods require an access token, whose role was granted
+	// the permission of the method if it has one
+	accessAuthenticated
+)
+
+type methodPolicy struct {
+	access     accessLevel
+	permission string
+}
+
+var (
+	publicPolicy = methodPolicy{access: accessPublic}
+	userPolicy   = methodPolicy{access: accessAuthenticated}
+	// manageUsersPolicy guards the back office RPCs acting on other users
+	manageUsersPolicy = methodPolicy{access: accessAuthenticated, permission: util.ManageUsersPermission}
+)
+
+// methodPolicies lists who may call each gRPC method. Methods missing here are denied
+var methodPolicies = map[string]methodPolicy{
+	pb.SimpleBank_CreateUser_FullMethodName:           publicPolicy,
+	pb.SimpleBank_UpdateUser_FullMethodName:           userPolicy,
+	pb.SimpleBank_LoginUser_FullMethodName:            publicPolicy,
+	pb.SimpleBank_VerifyEmail_FullMethodName:          publicPolicy,
+	pb.SimpleBank_CreateAccount_FullMethodName:        {access: accessAuthenticated, permission: util.CreateAccountPermission},
+	pb.SimpleBank_GetAccount_FullMethodName:           userPolicy,
+	pb.SimpleBank_ListAccounts_FullMethodName:         userPolicy,
+	pb.SimpleBank_DeleteAccount_FullMethodName:        userPolicy,
+	pb.SimpleBank_TransferMoney_FullMethodName:        {access: accessAuthenticated, permission: util.CreateTransferPermission},
+	pb.SimpleBank_ListAccountEntries_FullMethodName:   userPolicy,
+	pb.SimpleBank_ExportStatement_FullMethodName:      userPolicy,
+	pb.SimpleBank_LogoutUser_FullMethodName:           userPolicy,
+	pb.SimpleBank_ListSessions_FullMethodName:         userPolicy,
+	pb.SimpleBank_RevokeSession_FullMethodName:        userPolicy,
+	pb.SimpleBank_RevokeAllSessions_FullMethodName:    userPolicy,
+	pb.SimpleBank_RenewAccessToken_FullMethodName:     publicPolicy,
+	pb.SimpleBank_VerifyMFA_FullMethodName:            publicPolicy,
+	pb.SimpleBank_RequestPasswordReset_FullMethodName: publicPolicy,
+	pb.SimpleBank_ResetPassword_FullMethodName:        publicPolicy,
+
+	pb.SimpleBank_ListRolePermissions_FullMethodName:   {access: accessAuthenticated, permission: util.ManageRolesPermission},
+	pb.SimpleBank_UpdateRolePermissions_FullMethodName: {access: accessAuthenticated, permission: util.ManageRolesPermission},
+
+	pb.SimpleBank_ListUsers_FullMethodName:   manageUsersPolicy,
+	pb.SimpleBank_GetUser_FullMethodName:     manageUsersPolicy,
+	pb.SimpleBank_SetUserRole_FullMethodName: manageUsersPolicy,
+	pb.SimpleBank_DisableUser_FullMethodName: manageUsersPolicy,
+	pb.SimpleBank_EnableUser_FullMethodName:  manageUsersPolicy,
+
+	// users that must enrol before they can log in authenticate with an MFA challenge token instead
+	pb.SimpleBank_EnrollTOTP_FullMethodName:  {access: accessOptional},
+	pb.SimpleBank_ConfirmTOTP_FullMethodName: {access: accessOptional},
+
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName:      publicPolicy,
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: publicPolicy,
+
+	// probes of the orchestrator carry no token
+	healthpb.Health_Check_FullMethodName: publicPolicy,
+	healthpb.Health_Watch_FullMethodName: publicPolicy,
+}
@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"context"
+	"log/slog"
+	"main/ratelimit"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// httpRateLimitMethod is the method whose limit applies to every request of the HTTP gateway
+const httpRateLimitMethod = "HTTP"
+
+// RateLimitUnaryInterceptor limits unary calls per method and caller. It must run
+// after the auth interceptor to limit authenticated users by their username
+func (s *Server) RateLimitUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.limitCall(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// RateLimitStreamInterceptor limits streaming calls per method and caller
+func (s *Server) RateLimitStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.limitCall(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+func (s *Server) limitCall(ctx context.Context, fullMethod string) error {
+	caller := ""
+	if payload, err := authPayloadFromContext(ctx); err == nil {
+		caller = ratelimit.UserCaller(payload.Username)
+	} else if clientIP := s.extractMetaData(ctx).ClientIP; clientIP != "" {
+		caller = ratelimit.IPCaller(clientIP)
+	}
+
+	retryAfter := s.allowRequest(ctx, fullMethod, caller)
+	if retryAfter > 0 {
+		return tooManyRequestsError(ctx, retryAfter)
+	}
+
+	return nil
+}
+
+// HttpRateLimiter limits the requests of each caller to the HTTP gateway. Calls proxied
+// to the gRPC server are limited again there by the limit of their method
+func (s *Server) HttpRateLimiter(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		retryAfter := s.allowRequest(r.Context(), httpRateLimitMethod, s.httpCaller(r))
+		if retryAfter > 0 {
+			writeTooManyRequests(w, retryAfter)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// httpCaller identifies the caller by the username of a valid access token or by its
+// address, taken from X-Forwarded-For when the request came through a trusted proxy
+func (s *Server) httpCaller(r *http.Request) string {
+	fields := strings.Fields(r.Header.Get(authorizationHeader))
+	if len(fields) == 2 && strings.ToLower(fields[0]) == authorizationBearer {
+		if payload, err := s.tokenMaker.VerifyToken(fields[1]); err == nil {
+			return ratelimit.UserCaller(payload.Username)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return ratelimit.IPCaller(s.clientIP(host, r.Header.Values(xForwardedForHeader)))
+}
+
+// allowRequest returns how long the caller has to wait before calling the method.
+// Callers that cannot be identified are not limited, and when the limiter store is
+// unavailable requests are let through rather than failing the whole API
+func (s *Server) allowRequest(ctx context.Context, method string, caller string) time.Duration {
+	if caller == "" {
+		return 0
+	}
+
+	retryAfter, err := s.rateLimiter.Allow(ctx, method, caller)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to apply rate limit", slog.String("method", method), slog.String("error", err.Error()))
+		return 0
+	}
+
+	return retryAfter
+}
+
+// writeTooManyRequests responds like the gateway does to a ResourceExhausted status
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	st := status.Newf(codes.ResourceExhausted, "too many requests, retry after %d seconds", seconds)
+
+	body, err := protojson.Marshal(st.Proto())
+	if err != nil {
+		body = []byte(`{"code":8,"message":"too many requests"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write(body)
+}
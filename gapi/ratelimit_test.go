@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/pb"
+	"main/ratelimit"
+	"main/util"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+func TestRateLimitUnaryInterceptor(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+	server.rateLimiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Limit{}, map[string]ratelimit.Limit{
+		"LoginUser":    {Requests: 1, Interval: time.Minute},
+		"ListAccounts": {Requests: 1, Interval: time.Minute},
+	})
+
+	// call chains the interceptors in the order the gRPC server runs them
+	call := func(ctx context.Context, method string) error {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		}
+
+		_, err := server.AuthUnaryInterceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return server.RateLimitUnaryInterceptor(ctx, req, info, handler)
+		})
+		return err
+	}
+
+	newPeerContext := func(ip string) context.Context {
+		addr := &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	}
+
+	// anonymous callers are limited by their address
+	require.NoError(t, call(newPeerContext("10.0.0.1"), pb.SimpleBank_LoginUser_FullMethodName))
+	requireStatusCode(t, codes.ResourceExhausted, call(newPeerContext("10.0.0.1"), pb.SimpleBank_LoginUser_FullMethodName))
+	require.NoError(t, call(newPeerContext("10.0.0.2"), pb.SimpleBank_LoginUser_FullMethodName))
+
+	// authenticated callers are limited by their username
+	user1Ctx := newContextWithBearerToken(t, server.tokenMaker, "user1", util.DepositorRole, time.Minute)
+	user2Ctx := newContextWithBearerToken(t, server.tokenMaker, "user2", util.DepositorRole, time.Minute)
+
+	require.NoError(t, call(user1Ctx, pb.SimpleBank_ListAccounts_FullMethodName))
+	requireStatusCode(t, codes.ResourceExhausted, call(user1Ctx, pb.SimpleBank_ListAccounts_FullMethodName))
+	require.NoError(t, call(user2Ctx, pb.SimpleBank_ListAccounts_FullMethodName))
+
+	// methods without their own limit use the unlimited default
+	require.NoError(t, call(user1Ctx, pb.SimpleBank_ListSessions_FullMethodName))
+	require.NoError(t, call(user1Ctx, pb.SimpleBank_ListSessions_FullMethodName))
+}
+
+func TestHttpRateLimiter(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+	server.rateLimiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 2, Interval: time.Minute}, nil)
+
+	handler := server.HttpRateLimiter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	accessToken, _, err := server.tokenMaker.CreateToken("user1", util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	serve := func(remoteAddr string, accessToken string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/v1/accounts", nil)
+		request.RemoteAddr = remoteAddr
+		if accessToken != "" {
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:50000", "").Code)
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:50001", "").Code)
+
+	recorder := serve("10.0.0.1:50002", "")
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "30", recorder.Header().Get("Retry-After"))
+	require.Contains(t, recorder.Body.String(), "too many requests")
+
+	// the same address is not limited once the user is known
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:50003", accessToken).Code)
+}
+
+func TestHttpRateLimiterForwardedFor(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+	server.rateLimiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 1, Interval: time.Minute}, nil)
+
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	server.trustedProxies = trustedProxies
+
+	handler := server.HttpRateLimiter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(remoteAddr string, forwardedFor string) int {
+		request := httptest.NewRequest(http.MethodGet, "/v1/accounts", nil)
+		request.RemoteAddr = remoteAddr
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	// clients behind a trusted load balancer each get their own bucket
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:50000", "198.51.100.1"))
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:50001", "198.51.100.2"))
+	require.Equal(t, http.StatusTooManyRequests, serve("10.0.0.1:50002", "198.51.100.1"))
+
+	// an untrusted client cannot pick a fresh bucket by forging the header
+	require.Equal(t, http.StatusOK, serve("203.0.113.9:50000", "198.51.100.3"))
+	require.Equal(t, http.StatusTooManyRequests, serve("203.0.113.9:50001", "198.51.100.4"))
+}
@@ -0,0 +1,124 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/database/db"
+	"main/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := s.refreshTokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	if err := s.revocationChecker.Check(ctx, refreshPayload); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %v", err)
+	}
+
+	session, err := s.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get session: %v", err)
+	}
+
+	if session.IsBlocked {
+		return nil, status.Errorf(codes.Unauthenticated, "blocked session")
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect session user")
+	}
+
+	if session.RefreshToken != req.GetRefreshToken() {
+		return nil, status.Errorf(codes.Unauthenticated, "mismatched session token")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "expired session")
+	}
+
+	if session.RotatedAt.Valid {
+		return nil, s.blockSessionFamily(ctx, session)
+	}
+
+	accessToken, accessPayload, err := s.tokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, s.config.TokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token: %v", err)
+	}
+
+	// the rotated refresh token keeps the expiry of the family so renewing never extends a login
+	refreshToken, newRefreshPayload, err := s.refreshTokenMaker.CreateToken(refreshPayload.Username, refreshPayload.Role, time.Until(session.ExpiresAt))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token: %v", err)
+	}
+
+	metaData := s.extractMetaData(ctx)
+
+	txResult, err := s.store.RotateSessionTx(ctx, &db.RotateSessionTxParams{
+		SessionID: session.ID,
+		CreateSessionParams: db.CreateSessionParams{
+			ID:           newRefreshPayload.ID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    metaData.UserAgent,
+			ClientIp:     metaData.ClientIP,
+			IsBlocked:    false,
+			ExpiresAt:    newRefreshPayload.ExpiredAt,
+			FamilyID:     session.FamilyID,
+		},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, s.blockSessionFamily(ctx, session)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to rotate session: %v", err)
+	}
+
+	response := &pb.RenewAccessTokenResponse{
+		SessionId:             txResult.Session.ID.String(),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}
+
+	return response, nil
+}
+
+// blockSessionFamily blocks every session descending from the same login
+// after an already rotated refresh token was presented again
+func (s *Server) blockSessionFamily(ctx context.Context, session *db.Session) error {
+	_, err := s.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to block session family: %v", err)
+	}
+
+	return status.Errorf(codes.Unauthenticated, "refresh token reuse detected, all sessions of this login are revoked")
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetRefreshToken() == "" {
+		violations = append(violations, fieldViolation("refresh_token", fmt.Errorf("must be provided")))
+	}
+
+	return violations
+}
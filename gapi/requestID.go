@@ -0,0 +1,56 @@
+package gapi
+
+import (
+	"context"
+	"main/logging"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDUnaryInterceptor takes the request id sent by the caller or creates one,
+// puts it in the context for logging and returns it in the response header
+func RequestIDUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+// RequestIDStreamInterceptor does for streaming calls what RequestIDUnaryInterceptor does for unary calls
+func RequestIDStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: stream, ctx: withRequestID(stream.Context())})
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.RequestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	requestID = logging.RequestIDOrNew(requestID)
+	grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDMetadataKey, requestID))
+
+	return logging.NewContext(ctx, requestID)
+}
+
+// HttpRequestID takes the X-Request-ID header of the request or creates one, puts it in
+// the request context and returns it in the response. The gateway forwards it to gRPC
+func HttpRequestID(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := logging.RequestIDOrNew(r.Header.Get(logging.RequestIDHeader))
+		w.Header().Set(logging.RequestIDHeader, requestID)
+
+		handler.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), requestID)))
+	})
+}
+
+// GatewayRequestIDMetadata passes the request id of a gateway request on to the gRPC call
+func GatewayRequestIDMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	requestID := logging.RequestID(r.Context())
+	if requestID == "" {
+		return nil
+	}
+
+	return metadata.Pairs(logging.RequestIDMetadataKey, requestID)
+}
@@ -0,0 +1,94 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"main/logging"
+	"main/pb"
+	"main/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestIDUnaryInterceptor(t *testing.T) {
+	var buffer bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(logging.NewLogger(&buffer, "production"))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	server := newTestServer(t, nil, nil)
+
+	// call chains the interceptors in the order the gRPC server runs them
+	call := func(ctx context.Context) string {
+		info := &grpc.UnaryServerInfo{FullMethod: pb.SimpleBank_ListAccounts_FullMethodName}
+
+		var requestID string
+		handler := func(ctx context.Context, req any) (any, error) {
+			requestID = logging.RequestID(ctx)
+			return nil, nil
+		}
+
+		_, err := RequestIDUnaryInterceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return GrpcLogger(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				return server.AuthUnaryInterceptor(ctx, req, info, handler)
+			})
+		})
+		require.NoError(t, err)
+
+		return requestID
+	}
+
+	ctx := newContextWithBearerToken(t, server.tokenMaker, "user1", util.DepositorRole, time.Minute)
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	// the request id sent by the caller is kept
+	requestID := call(metadata.NewIncomingContext(ctx, metadata.Join(md, metadata.Pairs(logging.RequestIDMetadataKey, "abc-123"))))
+	require.Equal(t, "abc-123", requestID)
+
+	// the log record of the call carries the request id and the username from the token
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	require.Equal(t, "abc-123", record["request_id"])
+	require.Equal(t, "user1", record["username"])
+
+	// a missing or unsafe request id is replaced by a new one
+	require.NotEmpty(t, call(ctx))
+
+	requestID = call(metadata.NewIncomingContext(ctx, metadata.Join(md, metadata.Pairs(logging.RequestIDMetadataKey, "bad\nid"))))
+	require.NotEmpty(t, requestID)
+	require.NotEqual(t, "bad\nid", requestID)
+}
+
+func TestHttpRequestID(t *testing.T) {
+	var requestID string
+	handler := HttpRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = logging.RequestID(r.Context())
+
+		md := GatewayRequestIDMetadata(r.Context(), r)
+		require.Equal(t, []string{requestID}, md.Get(logging.RequestIDMetadataKey))
+	}))
+
+	// the request id sent by the client is kept and returned
+	request := httptest.NewRequest(http.MethodGet, "/v1/accounts", nil)
+	request.Header.Set(logging.RequestIDHeader, "abc-123")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, "abc-123", requestID)
+	require.Equal(t, "abc-123", recorder.Header().Get(logging.RequestIDHeader))
+
+	// a new request id is created when the client sends none
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/accounts", nil))
+
+	require.NotEmpty(t, requestID)
+	require.Equal(t, requestID, recorder.Header().Get(logging.RequestIDHeader))
+}
@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+	"main/pb"
+	"main/validate"
+	"main/worker"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	violations := validateRequestPasswordResetRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := s.store.GetUserByEmail(ctx, req.GetEmail())
+	if err != nil {
+		// answer the same way for unknown emails so the API cannot be used to find registered users
+		if err == pgx.ErrNoRows {
+			return &pb.RequestPasswordResetResponse{}, nil
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+
+	taskPayload := &worker.PayloadSendPasswordReset{
+		Username: user.Username,
+	}
+
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	err = s.taskDistributor.DistributeTaskSendPasswordReset(ctx, taskPayload, opts...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to distribute task to send password reset email: %v", err)
+	}
+
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+func validateRequestPasswordResetRequest(req *pb.RequestPasswordResetRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+
+	return violations
+}
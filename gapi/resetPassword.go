@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/util"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	violations := validateResetPasswordRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	hashedPassword, err := util.HashedPassword(req.GetNewPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+
+	txResult, err := s.store.ResetPasswordTx(ctx, &db.ResetPasswordTxParams{
+		ResetId:        req.GetResetId(),
+		SecretCode:     req.GetSecretCode(),
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "password reset link is invalid, used or expired")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to reset password: %v", err)
+	}
+
+	s.revocationChecker.Forget(txResult.User.Username)
+
+	return &pb.ResetPasswordResponse{}, nil
+}
+
+func validateResetPasswordRequest(req *pb.ResetPasswordRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateId(req.GetResetId()); err != nil {
+		violations = append(violations, fieldViolation("reset_id", err))
+	}
+
+	if err := validate.ValidateSecretCode(req.GetSecretCode()); err != nil {
+		violations = append(violations, fieldViolation("secret_code", err))
+	}
+
+	if err := validate.ValidatePassword(req.GetNewPassword()); err != nil {
+		violations = append(violations, fieldViolation("new_password", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+	"main/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	revokedCount, err := s.store.BlockUserSessions(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to block sessions: %v", err)
+	}
+
+	response := &pb.RevokeAllSessionsResponse{
+		RevokedCount: revokedCount,
+	}
+
+	return response, nil
+}
@@ -3,33 +3,102 @@ package gapi
 import (
 	"fmt"
 	"main/database/db"
+	"main/lockout"
+	"main/pagination"
 	"main/pb"
+	"main/permission"
+	"main/ratelimit"
+	"main/revocation"
 	"main/token"
+	"main/totp"
 	"main/util"
 	"main/worker"
+	"net"
 )
 
 // Server serves gRPC request for our banking service.
 type Server struct {
 	pb.UnimplementedSimpleBankServer
-	config          *util.ConfigDatabase
-	store           db.Store
-	tokenMaker      token.Maker
-	taskDistributor worker.TaskDistributor
+	config        *util.ConfigDatabase
+	store         db.Store
+	tokenMaker    token.Maker
+	mfaTokenMaker token.Maker
+	// refreshTokenMaker signs refresh tokens, so they never pass as access tokens
+	refreshTokenMaker token.Maker
+	// revocationChecker rejects tokens issued before the last password change
+	revocationChecker *revocation.Checker
+	// permissionChecker resolves the permissions granted to the role of a token
+	permissionChecker *permission.Checker
+	pageTokenMaker    *pagination.PageTokenMaker
+	taskDistributor   worker.TaskDistributor
+	// loginGuard slows down and locks out password guessing
+	loginGuard *lockout.Guard
+	// rateLimiter limits how often each caller may call each method
+	rateLimiter *ratelimit.Limiter
+	// totpCipher encrypts TOTP secrets at rest
+	totpCipher *totp.Cipher
+	// trustedProxies may tell the client address in X-Forwarded-For
+	trustedProxies []*net.IPNet
 }
 
 // NewServer creates a new gRPC server
 func NewServer(store db.Store, cfg *util.ConfigDatabase, taskDistributor worker.TaskDistributor) (*Server, error) {
-	tokenMaker, err := token.NewPASETOMaker(cfg.SecretKey)
+	tokenMaker, err := token.NewMaker(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	// challenge tokens are signed with their own key so they can never pass as access tokens
+	mfaTokenMaker, err := token.NewPASETOMaker(util.DeriveKey(cfg.SecretKey, "mfa-challenge"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create mfa token maker: %w", err)
+	}
+
+	// refresh tokens are signed with their own key so they can never pass as access tokens either
+	refreshTokenMaker, err := token.NewPASETOMaker(util.DeriveKey(cfg.SecretKey, "refresh-token"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create refresh token maker: %w", err)
+	}
+
+	loginGuard, err := lockout.NewGuardFromConfig(cfg, store)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create login guard: %w", err)
+	}
+
+	rateLimiter, err := ratelimit.NewLimiterFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create rate limiter: %w", err)
+	}
+
+	totpKey := cfg.TOTPEncryptionKey
+	if totpKey == "" {
+		totpKey = util.DeriveKey(cfg.SecretKey, "totp-secret")
+	}
+
+	totpCipher, err := totp.NewCipher(totpKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create totp cipher: %w", err)
+	}
+
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse trusted proxies: %w", err)
+	}
+
 	server := &Server{
-		config:          cfg,
-		store:           store,
-		tokenMaker:      tokenMaker,
-		taskDistributor: taskDistributor,
+		config:            cfg,
+		store:             store,
+		tokenMaker:        tokenMaker,
+		mfaTokenMaker:     mfaTokenMaker,
+		refreshTokenMaker: refreshTokenMaker,
+		revocationChecker: revocation.NewChecker(store, revocation.DefaultCacheTTL),
+		permissionChecker: permission.NewChecker(store, permission.DefaultCacheTTL),
+		loginGuard:        loginGuard,
+		rateLimiter:       rateLimiter,
+		pageTokenMaker:    pagination.NewPageTokenMaker(util.DeriveKey(cfg.SecretKey, "page-token")),
+		taskDistributor:   taskDistributor,
+		totpCipher:        totpCipher,
+		trustedProxies:    trustedProxies,
 	}
 
 	return server, nil
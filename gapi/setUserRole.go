@@ -0,0 +1,62 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/pb"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.SetUserRoleResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSetUserRoleRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.GetUsername() == authPayload.Username {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot change your own role")
+	}
+
+	result, err := s.store.ChangeUserRoleTx(ctx, &db.ChangeUserRoleTxParams{
+		Username: req.GetUsername(),
+		Role:     req.GetRole(),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to change user role: %v", err)
+	}
+
+	// reject the access tokens carrying the old role right away on this instance
+	s.revocationChecker.Forget(req.GetUsername())
+
+	response := &pb.SetUserRoleResponse{
+		User: convertUser(result.User),
+	}
+
+	return response, nil
+}
+
+func validateSetUserRoleRequest(req *pb.SetUserRoleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+
+	if err := validate.ValidateRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	return violations
+}
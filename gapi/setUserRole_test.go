@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/revocation"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestSetUserRoleRevokesOldRole(t *testing.T) {
+	admin := &db.User{Username: "admin", Role: util.BankerRole}
+	banker := &db.User{Username: "banker", Role: util.BankerRole}
+
+	storeCtrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(storeCtrl)
+	server := newTestServer(t, store, nil)
+	server.revocationChecker = revocation.NewChecker(store, time.Minute)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).AnyTimes().Return(admin, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(banker.Username)).Times(1).Return(banker, nil)
+	store.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(1).Return([]*db.User{}, nil)
+
+	bankerCtx := newContextWithBearerToken(t, server.tokenMaker, banker.Username, banker.Role, time.Minute)
+	_, err := callRPC(server, bankerCtx, pb.SimpleBank_ListUsers_FullMethodName, &pb.ListUsersRequest{}, server.ListUsers)
+	require.NoError(t, err)
+
+	demoted := &db.User{Username: banker.Username, Role: util.DepositorRole, RoleChangedAt: time.Now().Add(time.Second)}
+	store.EXPECT().ChangeUserRoleTx(gomock.Any(), gomock.Eq(&db.ChangeUserRoleTxParams{
+		Username: banker.Username,
+		Role:     util.DepositorRole,
+	})).Times(1).Return(&db.ChangeUserRoleTxResult{User: demoted}, nil)
+
+	adminCtx := newContextWithBearerToken(t, server.tokenMaker, admin.Username, admin.Role, time.Minute)
+	req := &pb.SetUserRoleRequest{Username: banker.Username, Role: util.DepositorRole}
+	_, err = callRPC(server, adminCtx, pb.SimpleBank_SetUserRole_FullMethodName, req, server.SetUserRole)
+	require.NoError(t, err)
+
+	// the access token still says banker, but the next call sees the role change
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(banker.Username)).Times(1).Return(demoted, nil)
+	store.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err = callRPC(server, bankerCtx, pb.SimpleBank_ListUsers_FullMethodName, &pb.ListUsersRequest{}, server.ListUsers)
+	requireStatusCode(t, codes.Unauthenticated, err)
+}
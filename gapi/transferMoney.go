@@ -0,0 +1,141 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/database/db"
+	"main/metrics"
+	"main/pb"
+	"main/validate"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) TransferMoney(ctx context.Context, req *pb.TransferMoneyRequest) (*pb.TransferMoneyResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	idempotencyKey, hasIdempotencyKey := idempotencyKeyFromContext(ctx, req)
+
+	violations := validateTransferMoneyRequest(req, idempotencyKey, hasIdempotencyKey)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := s.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account doesn't belong to the authenticated user")
+	}
+
+	_, err = s.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.TransferTxParams{
+		CreateTransferParams: db.CreateTransferParams{
+			FromAccountID: req.GetFromAccountId(),
+			ToAccountID:   req.GetToAccountId(),
+			Amount:        req.GetAmount(),
+		},
+		Username:       authPayload.Username,
+		IdempotencyKey: idempotencyKey,
+	}
+
+	result, err := s.store.TransferTx(ctx, &arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] has insufficient funds", fromAccount.ID)
+		}
+
+		if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+			return nil, status.Errorf(codes.AlreadyExists, "idempotency key already used")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to transfer money: %v", err)
+	}
+
+	if !result.Replayed {
+		metrics.ObserveTransfer(req.GetCurrency(), req.GetAmount())
+	}
+
+	response := &pb.TransferMoneyResponse{
+		Transfer:    convertTransfer(&result.Transfer),
+		FromEntry:   convertEntry(&result.FromEntry),
+		ToEntry:     convertEntry(&result.ToEntry),
+		FromAccount: convertAccount(&result.FromAccount),
+		ToAccount:   convertAccount(&result.ToAccount),
+	}
+
+	return response, nil
+}
+
+// idempotencyKeyFromContext returns the idempotency key of the request. The Idempotency-Key
+// header, forwarded by the gateway as metadata, takes precedence over the body field
+func idempotencyKeyFromContext(ctx context.Context, req *pb.TransferMoneyRequest) (string, bool) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+			return values[0], true
+		}
+	}
+
+	return req.GetIdempotencyKey(), req.IdempotencyKey != nil
+}
+
+func (s *Server) validAccount(ctx context.Context, accountId int64, currency string) (*db.Account, error) {
+	account, err := s.store.GetAccount(ctx, accountId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", accountId)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
+	}
+
+	if account.Currency != currency {
+		return nil, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+func validateTransferMoneyRequest(req *pb.TransferMoneyRequest, idempotencyKey string, hasIdempotencyKey bool) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+
+	if err := validate.ValidateId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+
+	if err := validate.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+
+	if err := validate.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if hasIdempotencyKey {
+		if err := validate.ValidateIdempotencyKey(idempotencyKey); err != nil {
+			violations = append(violations, fieldViolation("idempotency_key", err))
+		}
+	}
+
+	return violations
+}
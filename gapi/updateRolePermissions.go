@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/pb"
+	"main/util"
+	"main/validate"
+	"slices"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) UpdateRolePermissions(ctx context.Context, req *pb.UpdateRolePermissionsRequest) (*pb.UpdateRolePermissionsResponse, error) {
+	authPayload, err := authPayloadFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateRolePermissionsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// otherwise nobody with the role could undo the change
+	if req.GetRole() == authPayload.Role && !slices.Contains(req.GetPermissions(), util.ManageRolesPermission) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot revoke %s from your own role", util.ManageRolesPermission)
+	}
+
+	arg := &db.UpdateRolePermissionsTxParams{
+		Role:        req.GetRole(),
+		Permissions: req.GetPermissions(),
+	}
+
+	result, err := s.store.UpdateRolePermissionsTx(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update role permissions: %v", err)
+	}
+
+	s.permissionChecker.Forget(req.GetRole())
+
+	response := &pb.UpdateRolePermissionsResponse{
+		Role:        req.GetRole(),
+		Permissions: convertRolePermissions(result.RolePermissions),
+	}
+
+	return response, nil
+}
+
+func validateUpdateRolePermissionsRequest(req *pb.UpdateRolePermissionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validate.ValidateRole(req.GetRole()); err != nil {
+		violations = append(violations, fieldViolation("role", err))
+	}
+
+	seen := make(map[string]bool, len(req.GetPermissions()))
+	for i, permission := range req.GetPermissions() {
+		field := fmt.Sprintf("permissions[%d]", i)
+
+		if err := validate.ValidatePermission(permission); err != nil {
+			violations = append(violations, fieldViolation(field, err))
+		} else if seen[permission] {
+			violations = append(violations, fieldViolation(field, fmt.Errorf("duplicate permission")))
+		}
+
+		seen[permission] = true
+	}
+
+	return violations
+}
@@ -0,0 +1,155 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestUpdateRolePermissionsAPI(t *testing.T) {
+	permissions := []string{util.ReadAnyAccountPermission, util.ManageUsersPermission}
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateRolePermissionsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.UpdateRolePermissionsRequest{
+				Role:        util.SupportRole,
+				Permissions: permissions,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := &db.UpdateRolePermissionsTxParams{
+					Role:        util.SupportRole,
+					Permissions: permissions,
+				}
+
+				result := &db.UpdateRolePermissionsTxResult{
+					RolePermissions: []*db.RolePermission{
+						{Role: util.SupportRole, Permission: permissions[0]},
+						{Role: util.SupportRole, Permission: permissions[1]},
+					},
+				}
+
+				store.EXPECT().UpdateRolePermissionsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.SupportRole, res.GetRole())
+				require.Equal(t, permissions, res.GetPermissions())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req: &pb.UpdateRolePermissionsRequest{
+				Role:        util.DepositorRole,
+				Permissions: permissions,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateRolePermissionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "depositor", util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error) {
+				requireStatusCode(t, codes.PermissionDenied, err)
+			},
+		},
+		{
+			name: "RevokeOwnRoleManagement",
+			req: &pb.UpdateRolePermissionsRequest{
+				Role:        util.BankerRole,
+				Permissions: permissions,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateRolePermissionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error) {
+				requireStatusCode(t, codes.FailedPrecondition, err)
+			},
+		},
+		{
+			name: "InvalidRole",
+			req: &pb.UpdateRolePermissionsRequest{
+				Role:        "auditor",
+				Permissions: permissions,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateRolePermissionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "InvalidPermission",
+			req: &pb.UpdateRolePermissionsRequest{
+				Role:        util.SupportRole,
+				Permissions: []string{"accounts:everything"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateRolePermissionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+		{
+			name: "DuplicatePermission",
+			req: &pb.UpdateRolePermissionsRequest{
+				Role:        util.SupportRole,
+				Permissions: []string{util.ReadAnyAccountPermission, util.ReadAnyAccountPermission},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateRolePermissionsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateRolePermissionsResponse, err error) {
+				requireStatusCode(t, codes.InvalidArgument, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := callRPC(server, ctx, pb.SimpleBank_UpdateRolePermissions_FullMethodName, tc.req, server.UpdateRolePermissions)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -16,7 +16,7 @@ import (
 )
 
 func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	authPayload, err := s.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+	authPayload, err := authPayloadFromContext(ctx)
 	if err != nil {
 		return nil, unauthenticatedError(err)
 	}
@@ -26,14 +26,20 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Role != util.BankerRole && authPayload.Username != req.GetUsername() {
+	allowed, err := s.isOwnerOrPermitted(ctx, authPayload, req.GetUsername(), util.ManageUsersPermission)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check permission: %v", err)
+	}
+
+	if !allowed {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's username")
 	}
 
 	arg := db.UpdateUserParams{
-		Username: req.Username,
-		FullName: req.FullName,
-		Email:    req.Email,
+		Username:               req.Username,
+		FullName:               req.FullName,
+		Email:                  req.Email,
+		MonthlyStatementOptOut: req.MonthlyStatementOptOut,
 	}
 
 	if req.Password != nil {
@@ -50,11 +56,16 @@ func (s *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 	user, err := s.store.UpdateUser(ctx, &arg)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
 
 		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 	}
+
+	if arg.HashedPassword != nil {
+		s.revocationChecker.Forget(user.Username)
+	}
+
 	response := &pb.UpdateUserResponse{
 		User: convertUser(user),
 	}
@@ -164,7 +164,7 @@ func TestUpdateUserAPI(t *testing.T) {
 
 			ctx := tc.buildContext(t, server.tokenMaker)
 
-			resp, err := server.UpdateUser(ctx, tc.req)
+			resp, err := callRPC(server, ctx, pb.SimpleBank_UpdateUser_FullMethodName, tc.req, server.UpdateUser)
 			tc.checkResponse(t, resp, err)
 		})
 	}
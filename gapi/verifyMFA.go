@@ -0,0 +1,169 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/pb"
+	"main/totp"
+	"main/util"
+	"main/validate"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.VerifyMFAResponse, error) {
+	violations := validateVerifyMFARequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	challengePayload, err := s.mfaTokenMaker.VerifyToken(req.GetMfaChallengeToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid mfa challenge token: %v", err)
+	}
+
+	clientIP := s.extractMetaData(ctx).ClientIP
+	if err := s.checkLoginAllowed(ctx, challengePayload.Username, clientIP); err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUser(ctx, challengePayload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+
+	if user.IsDisabled {
+		return nil, status.Errorf(codes.PermissionDenied, "user is disabled")
+	}
+
+	if !user.IsTotpEnabled {
+		return nil, status.Errorf(codes.FailedPrecondition, "totp must be enrolled before logging in")
+	}
+
+	switch factor := req.GetFactor().(type) {
+	case *pb.VerifyMFARequest_Code:
+		err = s.useTOTPCode(ctx, user, factor.Code)
+	case *pb.VerifyMFARequest_RecoveryCode:
+		err = s.useRecoveryCode(ctx, user, factor.RecoveryCode)
+	}
+
+	if err != nil {
+		// wrong codes count like wrong passwords, so the second factor cannot be guessed either
+		if status.Code(err) == codes.Unauthenticated {
+			if err := s.recordLoginFailure(ctx, clientIP); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, err
+	}
+
+	err = s.loginGuard.RecordSuccess(ctx, user.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	login, err := s.createLoginResponse(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.VerifyMFAResponse{
+		Login: login,
+	}
+
+	return response, nil
+}
+
+// useTOTPCode checks the code and stores its time step, so it cannot be used again
+func (s *Server) useTOTPCode(ctx context.Context, user *db.User, code string) error {
+	secret, err := s.totpCipher.Decrypt(user.Username, user.TotpSecret)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to decrypt totp secret: %v", err)
+	}
+
+	step, ok := totp.Validate(code, secret, time.Now(), user.TotpLastStep)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "incorrect totp code")
+	}
+
+	// secrets stored before encryption at rest are encrypted on the way
+	encryptedSecret, err := s.totpCipher.Encrypt(user.Username, secret)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encrypt totp secret: %v", err)
+	}
+
+	// the update only succeeds for a later step, so concurrent replays of the code fail
+	_, err = s.store.UpdateUserTOTPLastStep(ctx, &db.UpdateUserTOTPLastStepParams{
+		Username:     user.Username,
+		TotpLastStep: step,
+		TotpSecret:   encryptedSecret,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return status.Errorf(codes.Unauthenticated, "totp code already used")
+		}
+
+		return status.Errorf(codes.Internal, "failed to save totp step: %v", err)
+	}
+
+	return nil
+}
+
+// useRecoveryCode marks the unused recovery code matching the input as used
+func (s *Server) useRecoveryCode(ctx context.Context, user *db.User, recoveryCode string) error {
+	recoveryCodes, err := s.store.ListUnusedRecoveryCodes(ctx, user.Username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list recovery codes: %v", err)
+	}
+
+	recoveryCode = totp.NormalizeRecoveryCode(recoveryCode)
+	for _, code := range recoveryCodes {
+		if util.CheckPassword(recoveryCode, code.HashedCode) != nil {
+			continue
+		}
+
+		_, err = s.store.UseRecoveryCode(ctx, code.ID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return status.Errorf(codes.Unauthenticated, "recovery code already used")
+			}
+
+			return status.Errorf(codes.Internal, "failed to use recovery code: %v", err)
+		}
+
+		return nil
+	}
+
+	return status.Errorf(codes.Unauthenticated, "incorrect recovery code")
+}
+
+func validateVerifyMFARequest(req *pb.VerifyMFARequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetMfaChallengeToken() == "" {
+		violations = append(violations, fieldViolation("mfa_challenge_token", fmt.Errorf("must be provided")))
+	}
+
+	switch factor := req.GetFactor().(type) {
+	case *pb.VerifyMFARequest_Code:
+		if err := validate.ValidateTOTPCode(factor.Code); err != nil {
+			violations = append(violations, fieldViolation("code", err))
+		}
+	case *pb.VerifyMFARequest_RecoveryCode:
+		if err := validate.ValidateRecoveryCode(factor.RecoveryCode); err != nil {
+			violations = append(violations, fieldViolation("recovery_code", err))
+		}
+	default:
+		violations = append(violations, fieldViolation("code", fmt.Errorf("either code or recovery_code must be provided")))
+	}
+
+	return violations
+}
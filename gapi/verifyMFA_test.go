@@ -0,0 +1,279 @@
+package gapi
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/pb"
+	"main/totp"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func randomTOTPUser(t *testing.T) (*db.User, string) {
+	user, password := randomUser(t)
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	user.TotpSecret = secret
+	user.IsTotpEnabled = true
+
+	return user, password
+}
+
+func TestLoginUserMFAChallenge(t *testing.T) {
+	user, password := randomTOTPUser(t)
+	banker, bankerPassword := randomUser(t)
+	banker.Role = util.BankerRole
+
+	testCases := []struct {
+		name          string
+		user          *db.User
+		password      string
+		checkResponse func(t *testing.T, server *Server, res *pb.LoginUserResponse, err error)
+	}{
+		{
+			name:     "TOTPEnabled",
+			user:     user,
+			password: password,
+			checkResponse: func(t *testing.T, server *Server, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetMfaRequired())
+				require.False(t, res.GetMfaEnrollmentRequired())
+				require.Empty(t, res.GetAccessToken())
+				require.Empty(t, res.GetRefreshToken())
+
+				// the challenge token must not be usable as an access token
+				_, err = server.tokenMaker.VerifyToken(res.GetMfaChallengeToken())
+				require.Error(t, err)
+				payload, err := server.mfaTokenMaker.VerifyToken(res.GetMfaChallengeToken())
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+			},
+		},
+		{
+			name:     "BankerMustEnroll",
+			user:     banker,
+			password: bankerPassword,
+			checkResponse: func(t *testing.T, server *Server, res *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetMfaRequired())
+				require.True(t, res.GetMfaEnrollmentRequired())
+				require.NotEmpty(t, res.GetMfaChallengeToken())
+				require.Empty(t, res.GetAccessToken())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(tc.user.Username)).Times(1).Return(tc.user, nil)
+			store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+
+			server := newTestServer(t, store, nil)
+			res, err := server.LoginUser(context.Background(), &pb.LoginUserRequest{
+				Username: tc.user.Username,
+				Password: tc.password,
+			})
+			tc.checkResponse(t, server, res, err)
+		})
+	}
+}
+
+func TestVerifyMFAAPI(t *testing.T) {
+	user, _ := randomTOTPUser(t)
+
+	recoveryCode := "ABCDE-FGHJK"
+	hashedRecoveryCode, err := util.HashedPassword(recoveryCode)
+	require.NoError(t, err)
+	recoveryCodes := []*db.RecoveryCode{
+		{ID: 1, Username: user.Username, HashedCode: hashedRecoveryCode},
+	}
+
+	validCode, err := totp.GenerateCode(user.TotpSecret, time.Now())
+	require.NoError(t, err)
+	invalidCode, err := totp.GenerateCode(user.TotpSecret, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	// the user after validCode was accepted
+	usedCodeUser := *user
+	usedCodeUser.TotpLastStep = time.Now().Add(totp.Period).Unix() / int64(totp.Period.Seconds())
+
+	testCases := []struct {
+		name          string
+		buildRequest  func(t *testing.T, server *Server) *pb.VerifyMFARequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.VerifyMFAResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: newMFAChallengeToken(t, server, user),
+					Factor:            &pb.VerifyMFARequest_Code{Code: validCode},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTOTPLastStep(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(ctx context.Context, arg *db.UpdateUserTOTPLastStepParams) (*db.User, error) {
+						// the plaintext secret of the user is encrypted on the way
+						require.Equal(t, user.Username, arg.Username)
+						require.Positive(t, arg.TotpLastStep)
+						require.NotEqual(t, user.TotpSecret, arg.TotpSecret)
+						return user, nil
+					})
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).Return(&db.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetLogin().GetAccessToken())
+				require.NotEmpty(t, res.GetLogin().GetRefreshToken())
+			},
+		},
+		{
+			name: "ReplayedCode",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: newMFAChallengeToken(t, server, user),
+					Factor:            &pb.VerifyMFARequest_Code{Code: validCode},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(&usedCodeUser, nil)
+				store.EXPECT().UpdateUserTOTPLastStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "ConcurrentlyReplayedCode",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: newMFAChallengeToken(t, server, user),
+					Factor:            &pb.VerifyMFARequest_Code{Code: validCode},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTOTPLastStep(gomock.Any(), gomock.Any()).Times(1).Return(nil, pgx.ErrNoRows)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				requireStatusCode(t, codes.Unauthenticated, err)
+			},
+		},
+		{
+			name: "RecoveryCode",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: newMFAChallengeToken(t, server, user),
+					Factor:            &pb.VerifyMFARequest_RecoveryCode{RecoveryCode: "abcdefghjk"},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListUnusedRecoveryCodes(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(recoveryCodes, nil)
+				store.EXPECT().UseRecoveryCode(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(recoveryCodes[0], nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(1).Return(&db.Session{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetLogin().GetAccessToken())
+			},
+		},
+		{
+			name: "IncorrectCode",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: newMFAChallengeToken(t, server, user),
+					Factor:            &pb.VerifyMFARequest_Code{Code: invalidCode},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "AccessTokenAsChallenge",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				accessToken, _, err := server.tokenMaker.CreateToken(user.Username, user.Role, time.Minute)
+				require.NoError(t, err)
+
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: accessToken,
+					Factor:            &pb.VerifyMFARequest_Code{Code: validCode},
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "MissingFactor",
+			buildRequest: func(t *testing.T, server *Server) *pb.VerifyMFARequest {
+				return &pb.VerifyMFARequest{
+					MfaChallengeToken: newMFAChallengeToken(t, server, user),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyMFAResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			res, err := server.VerifyMFA(context.Background(), tc.buildRequest(t, server))
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func newMFAChallengeToken(t *testing.T, server *Server, user *db.User) string {
+	challengeToken, _, err := server.mfaTokenMaker.CreateToken(user.Username, user.Role, mfaChallengeDuration)
+	require.NoError(t, err)
+
+	return challengeToken
+}
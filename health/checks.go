@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// Pinger is a database connection that can be pinged
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// RowQuerier runs a query returning one row
+type RowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// PostgresCheck checks that the database accepts connections
+func PostgresCheck(db Pinger) Check {
+	return func(ctx context.Context) error {
+		return db.Ping(ctx)
+	}
+}
+
+// RedisCheck checks that Redis, which holds the task queues, is reachable
+func RedisCheck(client redis.UniversalClient) Check {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// MigrationCheck checks that the database schema was migrated at least to the version this build
+// expects. A newer schema passes, so old pods stay ready while a rolling deploy migrates ahead of them
+func MigrationCheck(db RowQuerier, expectedVersion uint) Check {
+	return func(ctx context.Context) error {
+		var version int64
+		var dirty bool
+
+		err := db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if err != nil {
+			return fmt.Errorf("failed to get migration version: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d failed halfway", version)
+		}
+
+		if version < int64(expectedVersion) {
+			return fmt.Errorf("schema is at migration %d, expected at least %d", version, expectedVersion)
+		}
+
+		return nil
+	}
+}
+
+// LatestMigrationVersion returns the version of the last migration in the source
+func LatestMigrationVersion(sourceURL string) (uint, error) {
+	driver, err := source.Open(sourceURL)
+	if err != nil {
+		return 0, fmt.Errorf("cannot open migration source: %w", err)
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read first migration: %w", err)
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("cannot read migration after %d: %w", version, err)
+		}
+
+		version = next
+	}
+}
@@ -0,0 +1,168 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// checkTimeout bounds how long a single readiness check may take
+const checkTimeout = 2 * time.Second
+
+var errShuttingDown = errors.New("server is shutting down")
+
+// Check reports whether a dependency of the service is usable
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker decides whether the service is ready to take traffic and reports
+// it through the gRPC health service and the HTTP probe endpoints
+type Checker struct {
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+	grpcHealth   *health.Server
+}
+
+// NewChecker creates a new Checker
+func NewChecker() *Checker {
+	checker := &Checker{
+		grpcHealth: health.NewServer(),
+	}
+
+	// not ready until the first checks passed
+	checker.grpcHealth.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return checker
+}
+
+// AddCheck adds a check that has to pass for the service to be ready
+func (c *Checker) AddCheck(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// GRPCServer returns the grpc.health.v1.Health service to register on the gRPC server
+func (c *Checker) GRPCServer() healthpb.HealthServer {
+	return c.grpcHealth
+}
+
+// Shutdown marks the service as not serving, so that no new traffic is
+// routed to it while it drains
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+	c.grpcHealth.Shutdown()
+}
+
+// Check runs all checks in parallel and returns the error of each failed one
+func (c *Checker) Check(ctx context.Context) map[string]error {
+	failures := make(map[string]error)
+	if c.shuttingDown.Load() {
+		failures["server"] = errShuttingDown
+		return failures
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			if err := nc.check(ctx); err != nil {
+				mu.Lock()
+				failures[nc.name] = err
+				mu.Unlock()
+			}
+		}(nc)
+	}
+
+	wg.Wait()
+	return failures
+}
+
+// Watch runs the checks every interval and updates the status of the gRPC
+// health service until the context is done
+func (c *Checker) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.updateGRPCStatus(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) updateGRPCStatus(ctx context.Context) {
+	// once shut down the health server ignores further updates
+	status := healthpb.HealthCheckResponse_SERVING
+	if len(c.Check(ctx)) > 0 {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	c.grpcHealth.SetServingStatus("", status)
+}
+
+// LivenessHandler answers /healthz. The process is alive as long as it can serve
+// HTTP, failing dependencies only make it unready
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, "SERVING", nil)
+	})
+}
+
+// ReadinessHandler answers /readyz with the result of every check
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		failures := c.Check(r.Context())
+		for name, err := range failures {
+			slog.WarnContext(r.Context(), "readiness check failed", slog.String("check", name), slog.String("error", err.Error()))
+		}
+
+		if len(failures) > 0 {
+			writeStatus(w, http.StatusServiceUnavailable, "NOT_SERVING", failures)
+			return
+		}
+
+		writeStatus(w, http.StatusOK, "SERVING", nil)
+	})
+}
+
+// writeStatus responds with the names of the failed checks. Their errors are only
+// logged, since the probe endpoints are public
+func writeStatus(w http.ResponseWriter, statusCode int, status string, failures map[string]error) {
+	response := struct {
+		Status string   `json:"status"`
+		Failed []string `json:"failed,omitempty"`
+	}{
+		Status: status,
+	}
+
+	for name := range failures {
+		response.Failed = append(response.Failed, name)
+	}
+	slices.Sort(response.Failed)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func grpcStatus(t *testing.T, checker *Checker) healthpb.HealthCheckResponse_ServingStatus {
+	res, err := checker.GRPCServer().Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	return res.GetStatus()
+}
+
+func TestCheckerReadiness(t *testing.T) {
+	var redisErr error
+
+	checker := NewChecker()
+	checker.AddCheck("postgres", func(ctx context.Context) error { return nil })
+	checker.AddCheck("redis", func(ctx context.Context) error { return redisErr })
+
+	// not serving before the checks ran
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcStatus(t, checker))
+
+	checker.updateGRPCStatus(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, grpcStatus(t, checker))
+
+	recorder := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	redisErr = errors.New("dial tcp: connection refused")
+
+	checker.updateGRPCStatus(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcStatus(t, checker))
+
+	recorder = httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.JSONEq(t, `{"status":"NOT_SERVING","failed":["redis"]}`, recorder.Body.String())
+
+	// a failing dependency does not make the process unhealthy
+	recorder = httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestCheckerShutdown(t *testing.T) {
+	checker := NewChecker()
+	checker.AddCheck("postgres", func(ctx context.Context) error { return nil })
+
+	checker.updateGRPCStatus(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, grpcStatus(t, checker))
+
+	checker.Shutdown()
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcStatus(t, checker))
+
+	// passing checks cannot bring a server that is shutting down back
+	checker.updateGRPCStatus(context.Background())
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, grpcStatus(t, checker))
+
+	recorder := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
+
+// migrationRow is the schema_migrations row of a fake database
+type migrationRow struct {
+	version int64
+	dirty   bool
+}
+
+func (row migrationRow) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return row
+}
+
+func (row migrationRow) Scan(dest ...any) error {
+	*dest[0].(*int64) = row.version
+	*dest[1].(*bool) = row.dirty
+	return nil
+}
+
+func TestMigrationCheck(t *testing.T) {
+	require.NoError(t, MigrationCheck(migrationRow{version: 17}, 17)(context.Background()))
+
+	// a newer schema, migrated by a newer pod during a rolling deploy, is fine
+	require.NoError(t, MigrationCheck(migrationRow{version: 18}, 17)(context.Background()))
+
+	require.Error(t, MigrationCheck(migrationRow{version: 16}, 17)(context.Background()))
+	require.Error(t, MigrationCheck(migrationRow{version: 18, dirty: true}, 17)(context.Background()))
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	version, err := LatestMigrationVersion("file://../database/migration")
+	require.NoError(t, err)
+
+	// migrations are numbered from one without gaps
+	upMigrations, err := filepath.Glob("../database/migration/*.up.sql")
+	require.NoError(t, err)
+	require.Equal(t, uint(len(upMigrations)), version)
+}
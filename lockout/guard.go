@@ -0,0 +1,161 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/util"
+	"time"
+)
+
+// Policy decides how long a key has to wait after failed logins
+type Policy struct {
+	// BackoffAfter is the number of failures after which every further failure
+	// doubles the wait, starting at BackoffDelay. Zero disables the backoff
+	BackoffAfter int
+	BackoffDelay time.Duration
+	// LockoutAfter is the number of failures that lock the key for LockoutDuration.
+	// Zero disables the lockout
+	LockoutAfter    int
+	LockoutDuration time.Duration
+}
+
+// RetryAfter returns how long the key has to wait before its next attempt
+func (p Policy) RetryAfter(attempts Attempts, now time.Time) time.Duration {
+	var wait time.Duration
+
+	switch {
+	case p.LockoutAfter > 0 && attempts.Failures >= p.LockoutAfter:
+		wait = p.LockoutDuration
+	case p.BackoffAfter > 0 && attempts.Failures >= p.BackoffAfter:
+		wait = p.BackoffDelay << min(attempts.Failures-p.BackoffAfter, 30)
+		if p.LockoutDuration > 0 && (wait > p.LockoutDuration || wait <= 0) {
+			wait = p.LockoutDuration
+		}
+	default:
+		return 0
+	}
+
+	return max(attempts.LastFailureAt.Add(wait).Sub(now), 0)
+}
+
+// Guard throttles password guessing per username and per client IP
+type Guard struct {
+	store      Store
+	userPolicy Policy
+	ipPolicy   Policy
+	// window is how long failures are remembered
+	window time.Duration
+}
+
+// NewGuard creates a new Guard
+func NewGuard(store Store, userPolicy Policy, ipPolicy Policy) *Guard {
+	return &Guard{
+		store:      store,
+		userPolicy: userPolicy,
+		ipPolicy:   ipPolicy,
+		window:     max(userPolicy.LockoutDuration, ipPolicy.LockoutDuration),
+	}
+}
+
+// NewGuardFromConfig creates a Guard with the store and policies of the config.
+// Client IPs are only locked out, since many users may share one address
+func NewGuardFromConfig(cfg *util.ConfigDatabase, store db.Store) (*Guard, error) {
+	lockoutStore, err := NewStore(cfg, store)
+	if err != nil {
+		return nil, err
+	}
+
+	userPolicy := Policy{
+		BackoffAfter:    cfg.LoginBackoffAfter,
+		BackoffDelay:    cfg.LoginBackoffDelay,
+		LockoutAfter:    cfg.LoginLockoutAfter,
+		LockoutDuration: cfg.LoginLockoutDuration,
+	}
+
+	ipPolicy := Policy{
+		LockoutAfter:    cfg.LoginIPLockoutAfter,
+		LockoutDuration: cfg.LoginLockoutDuration,
+	}
+
+	return NewGuard(lockoutStore, userPolicy, ipPolicy), nil
+}
+
+// Check returns how long the client has to wait before it may try to log in as the user
+func (g *Guard) Check(ctx context.Context, username string, clientIP string) (time.Duration, error) {
+	now := time.Now()
+
+	attempts, err := g.store.Get(ctx, userKey(username), g.window)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get login attempts of user: %w", err)
+	}
+
+	retryAfter := g.userPolicy.RetryAfter(attempts, now)
+
+	if clientIP != "" {
+		attempts, err = g.store.Get(ctx, ipKey(clientIP), g.window)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get login attempts of client ip: %w", err)
+		}
+
+		retryAfter = max(retryAfter, g.ipPolicy.RetryAfter(attempts, now))
+	}
+
+	return retryAfter, nil
+}
+
+// Attempt starts a login of the client as the user and returns how long it has to
+// wait if it may not try now. The attempt counts as a failure of the user up front
+// and the lockout is decided on the new count, so concurrent guesses cannot all pass
+// the check before any of their failures is recorded. RecordSuccess forgets it again
+func (g *Guard) Attempt(ctx context.Context, username string, clientIP string) (time.Duration, error) {
+	retryAfter, err := g.Check(ctx, username, clientIP)
+	if err != nil || retryAfter > 0 {
+		return retryAfter, err
+	}
+
+	attempts, err := g.store.RecordFailure(ctx, userKey(username), g.window)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record login attempt of user: %w", err)
+	}
+
+	if g.userPolicy.LockoutAfter > 0 && attempts.Failures > g.userPolicy.LockoutAfter {
+		return g.userPolicy.LockoutDuration, nil
+	}
+
+	return 0, nil
+}
+
+// RecordFailure counts a failed login against the client IP. The user was
+// already counted by Attempt
+func (g *Guard) RecordFailure(ctx context.Context, clientIP string) error {
+	if clientIP == "" {
+		return nil
+	}
+
+	_, err := g.store.RecordFailure(ctx, ipKey(clientIP), g.window)
+	if err != nil {
+		return fmt.Errorf("failed to record login failure of client ip: %w", err)
+	}
+
+	return nil
+}
+
+// RecordSuccess forgets the failed logins of the user. Failures of the client IP
+// are kept, so one valid account cannot be used to reset guessing on others
+func (g *Guard) RecordSuccess(ctx context.Context, username string) error {
+	err := g.store.Reset(ctx, userKey(username))
+	if err != nil {
+		return fmt.Errorf("failed to reset login attempts of user: %w", err)
+	}
+
+	return nil
+}
+
+func userKey(username string) string {
+	return "user:" + username
+}
+
+func ipKey(clientIP string) string {
+	return "ip:" + clientIP
+}
@@ -0,0 +1,214 @@
+package lockout
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/util"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPolicyRetryAfter(t *testing.T) {
+	policy := Policy{
+		BackoffAfter:    3,
+		BackoffDelay:    time.Second,
+		LockoutAfter:    10,
+		LockoutDuration: 15 * time.Minute,
+	}
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		attempts Attempts
+		expected time.Duration
+	}{
+		{
+			name:     "NoFailures",
+			attempts: Attempts{},
+			expected: 0,
+		},
+		{
+			name:     "BelowBackoff",
+			attempts: Attempts{Failures: 2, LastFailureAt: now},
+			expected: 0,
+		},
+		{
+			name:     "FirstBackoff",
+			attempts: Attempts{Failures: 3, LastFailureAt: now},
+			expected: time.Second,
+		},
+		{
+			name:     "DoubledBackoff",
+			attempts: Attempts{Failures: 5, LastFailureAt: now},
+			expected: 4 * time.Second,
+		},
+		{
+			name:     "BackoffElapsed",
+			attempts: Attempts{Failures: 3, LastFailureAt: now.Add(-2 * time.Second)},
+			expected: 0,
+		},
+		{
+			name:     "LockedOut",
+			attempts: Attempts{Failures: 10, LastFailureAt: now.Add(-time.Minute)},
+			expected: 14 * time.Minute,
+		},
+		{
+			name:     "LockoutElapsed",
+			attempts: Attempts{Failures: 12, LastFailureAt: now.Add(-16 * time.Minute)},
+			expected: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, policy.RetryAfter(tc.attempts, now))
+		})
+	}
+}
+
+func TestPolicyBackoffCappedByLockoutDuration(t *testing.T) {
+	policy := Policy{BackoffAfter: 1, BackoffDelay: time.Second, LockoutDuration: time.Minute}
+	now := time.Now()
+
+	require.Equal(t, time.Minute, policy.RetryAfter(Attempts{Failures: 20, LastFailureAt: now}, now))
+	require.Equal(t, time.Minute, policy.RetryAfter(Attempts{Failures: 100, LastFailureAt: now}, now))
+}
+
+func TestGuardLocksOutUser(t *testing.T) {
+	ctx := context.Background()
+	guard := NewGuard(NewMemoryStore(), Policy{LockoutAfter: 2, LockoutDuration: time.Minute}, Policy{})
+
+	username := util.RandomOwner()
+	clientIP := "10.0.0.1"
+
+	for i := 0; i < 2; i++ {
+		retryAfter, err := guard.Attempt(ctx, username, clientIP)
+		require.NoError(t, err)
+		require.Zero(t, retryAfter)
+
+		require.NoError(t, guard.RecordFailure(ctx, clientIP))
+	}
+
+	retryAfter, err := guard.Check(ctx, username, clientIP)
+	require.NoError(t, err)
+	require.InDelta(t, time.Minute, retryAfter, float64(time.Second))
+
+	// other users are not affected by the lockout
+	retryAfter, err = guard.Check(ctx, util.RandomOwner(), clientIP)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestGuardConcurrentAttempts(t *testing.T) {
+	ctx := context.Background()
+	const lockoutAfter = 3
+	guard := NewGuard(NewMemoryStore(), Policy{LockoutAfter: lockoutAfter, LockoutDuration: time.Minute}, Policy{})
+
+	username := util.RandomOwner()
+
+	// every guess passes the check at once, but only lockoutAfter of them may try a password
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			retryAfter, err := guard.Attempt(ctx, username, "")
+			require.NoError(t, err)
+			if retryAfter == 0 {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(lockoutAfter), allowed.Load())
+}
+
+func TestGuardLocksOutClientIP(t *testing.T) {
+	ctx := context.Background()
+	guard := NewGuard(NewMemoryStore(), Policy{}, Policy{LockoutAfter: 3, LockoutDuration: time.Minute})
+
+	clientIP := "10.0.0.2"
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, guard.RecordFailure(ctx, clientIP))
+	}
+
+	retryAfter, err := guard.Check(ctx, util.RandomOwner(), clientIP)
+	require.NoError(t, err)
+	require.Positive(t, retryAfter)
+
+	// requests without a known client ip are only limited per user
+	retryAfter, err = guard.Check(ctx, util.RandomOwner(), "")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestGuardRecordSuccessResetsUser(t *testing.T) {
+	ctx := context.Background()
+	guard := NewGuard(NewMemoryStore(), Policy{LockoutAfter: 1, LockoutDuration: time.Minute}, Policy{})
+
+	username := util.RandomOwner()
+	retryAfter, err := guard.Attempt(ctx, username, "")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	retryAfter, err = guard.Check(ctx, username, "")
+	require.NoError(t, err)
+	require.Positive(t, retryAfter)
+
+	require.NoError(t, guard.RecordSuccess(ctx, username))
+
+	retryAfter, err = guard.Check(ctx, username, "")
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestPostgresStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	ctx := context.Background()
+	key := "user:" + util.RandomOwner()
+
+	store.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Eq(key)).Times(1).Return(nil, pgx.ErrNoRows)
+	store.EXPECT().
+		RecordLoginFailure(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg *db.RecordLoginFailureParams) (*db.LoginAttempt, error) {
+			require.Equal(t, key, arg.Key)
+			require.WithinDuration(t, time.Now().Add(-time.Minute), arg.WindowStart, time.Second)
+
+			return &db.LoginAttempt{Key: key, Failures: 1, LastFailureAt: time.Now()}, nil
+		})
+	store.EXPECT().
+		GetLoginAttempt(gomock.Any(), gomock.Eq(key)).
+		Times(1).
+		Return(&db.LoginAttempt{Key: key, Failures: 4, LastFailureAt: time.Now().Add(-2 * time.Minute)}, nil)
+
+	postgresStore := NewPostgresStore(store)
+
+	attempts, err := postgresStore.Get(ctx, key, time.Minute)
+	require.NoError(t, err)
+	require.Zero(t, attempts.Failures)
+
+	attempts, err = postgresStore.RecordFailure(ctx, key, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.Failures)
+
+	// failures older than the window are forgotten
+	attempts, err = postgresStore.Get(ctx, key, time.Minute)
+	require.NoError(t, err)
+	require.Zero(t, attempts.Failures)
+}
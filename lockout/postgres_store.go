@@ -0,0 +1,55 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"main/database/db"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresStore keeps failed login attempts in the login_attempts table
+type PostgresStore struct {
+	store db.Store
+}
+
+// NewPostgresStore creates a new PostgresStore
+func NewPostgresStore(store db.Store) *PostgresStore {
+	return &PostgresStore{
+		store: store,
+	}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string, window time.Duration) (Attempts, error) {
+	attempt, err := s.store.GetLoginAttempt(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Attempts{}, nil
+		}
+
+		return Attempts{}, err
+	}
+
+	if time.Since(attempt.LastFailureAt) > window {
+		return Attempts{}, nil
+	}
+
+	return Attempts{Failures: int(attempt.Failures), LastFailureAt: attempt.LastFailureAt}, nil
+}
+
+func (s *PostgresStore) RecordFailure(ctx context.Context, key string, window time.Duration) (Attempts, error) {
+	attempt, err := s.store.RecordLoginFailure(ctx, &db.RecordLoginFailureParams{
+		Key:         key,
+		WindowStart: time.Now().Add(-window),
+	})
+	if err != nil {
+		return Attempts{}, err
+	}
+
+	return Attempts{Failures: int(attempt.Failures), LastFailureAt: attempt.LastFailureAt}, nil
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	return s.store.DeleteLoginAttempt(ctx, key)
+}
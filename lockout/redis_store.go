@@ -0,0 +1,63 @@
+package lockout
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisKeyPrefix     = "login_attempts:"
+	failuresField      = "failures"
+	lastFailureAtField = "last_failure_at"
+)
+
+// RedisStore keeps failed login attempts in Redis hashes that expire after the window
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a new RedisStore
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string, window time.Duration) (Attempts, error) {
+	fields, err := s.client.HGetAll(ctx, redisKeyPrefix+key).Result()
+	if err != nil {
+		return Attempts{}, err
+	}
+
+	failures, _ := strconv.Atoi(fields[failuresField])
+	if failures == 0 {
+		return Attempts{}, nil
+	}
+
+	lastFailureAt, _ := strconv.ParseInt(fields[lastFailureAtField], 10, 64)
+
+	return Attempts{Failures: failures, LastFailureAt: time.UnixMilli(lastFailureAt)}, nil
+}
+
+func (s *RedisStore) RecordFailure(ctx context.Context, key string, window time.Duration) (Attempts, error) {
+	now := time.Now()
+	redisKey := redisKeyPrefix + key
+
+	pipe := s.client.TxPipeline()
+	failures := pipe.HIncrBy(ctx, redisKey, failuresField, 1)
+	pipe.HSet(ctx, redisKey, lastFailureAtField, now.UnixMilli())
+	pipe.PExpire(ctx, redisKey, window)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return Attempts{}, err
+	}
+
+	return Attempts{Failures: int(failures.Val()), LastFailureAt: now}, nil
+}
+
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	return s.client.Del(ctx, redisKeyPrefix+key).Err()
+}
@@ -0,0 +1,48 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"main/util"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	PostgresStoreType = "postgres"
+	RedisStoreType    = "redis"
+	MemoryStoreType   = "memory"
+)
+
+// Attempts are the recent failed logins of a key
+type Attempts struct {
+	Failures      int
+	LastFailureAt time.Time
+}
+
+// Store keeps failed login attempts. Failures are forgotten once no new
+// failure was recorded for the window
+type Store interface {
+	// Get returns the failed attempts of the key within the window
+	Get(ctx context.Context, key string, window time.Duration) (Attempts, error)
+	// RecordFailure adds a failed attempt to the key and returns the updated attempts
+	RecordFailure(ctx context.Context, key string, window time.Duration) (Attempts, error)
+	// Reset forgets the failed attempts of the key
+	Reset(ctx context.Context, key string) error
+}
+
+// NewStore creates the store selected by LOGIN_LOCKOUT_STORE
+func NewStore(cfg *util.ConfigDatabase, store db.Store) (Store, error) {
+	switch cfg.LoginLockoutStore {
+	case "", PostgresStoreType:
+		return NewPostgresStore(store), nil
+	case RedisStoreType:
+		return NewRedisStore(redis.NewClient(&redis.Options{Addr: cfg.RedisAddress})), nil
+	case MemoryStoreType:
+		return NewMemoryStore(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported login lockout store: %s", cfg.LoginLockoutStore)
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+const (
+	// RequestIDHeader is the HTTP header carrying the request id
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDMetadataKey is the gRPC metadata key carrying the request id
+	RequestIDMetadataKey = "x-request-id"
+)
+
+// NewLogger creates the logger of the service: text for dev, JSON everywhere else.
+// Records logged with a context carry the request id and username of that context,
+// and sensitive attributes are redacted
+func NewLogger(w io.Writer, environment string) *slog.Logger {
+	var handler slog.Handler
+	options := &slog.HandlerOptions{ReplaceAttr: redactAttr}
+
+	if environment == "dev" {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(NewContextHandler(handler))
+}
+
+type requestInfoKey struct{}
+
+// requestInfo is shared by everything handling one request, so that a username
+// set deep in the call chain shows up in the logs of the outer layers too
+type requestInfo struct {
+	mu        sync.Mutex
+	requestID string
+	username  string
+}
+
+// NewContext returns a context that carries the request id
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, &requestInfo{requestID: requestID})
+}
+
+// RequestID returns the request id of the context, or an empty string
+func RequestID(ctx context.Context) string {
+	info, ok := ctx.Value(requestInfoKey{}).(*requestInfo)
+	if !ok {
+		return ""
+	}
+
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	return info.requestID
+}
+
+// SetUsername records the authenticated user of the request of the context
+func SetUsername(ctx context.Context, username string) {
+	info, ok := ctx.Value(requestInfoKey{}).(*requestInfo)
+	if !ok {
+		return
+	}
+
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	info.username = username
+}
+
+// ContextHandler adds the request id and username of the context to every record
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler creates a new ContextHandler around the handler
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{
+		Handler: handler,
+	}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.mu.Lock()
+		requestID, username := info.requestID, info.username
+		info.mu.Unlock()
+
+		if requestID != "" {
+			record.AddAttrs(slog.String("request_id", requestID))
+		}
+
+		if username != "" {
+			record.AddAttrs(slog.String("username", username))
+		}
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewContextHandler(h.Handler.WithAttrs(attrs))
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return NewContextHandler(h.Handler.WithGroup(name))
+}
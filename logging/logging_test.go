@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextHandler(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewLogger(&buffer, "production").With("component", "test")
+
+	ctx := NewContext(context.Background(), "abc-123")
+	SetUsername(ctx, "alice")
+	logger.InfoContext(ctx, "with request")
+
+	logger.InfoContext(context.Background(), "without request")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Equal(t, "abc-123", record["request_id"])
+	require.Equal(t, "alice", record["username"])
+	require.Equal(t, "test", record["component"])
+
+	record = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	require.NotContains(t, record, "request_id")
+	require.NotContains(t, record, "username")
+}
+
+func TestRequestIDOrNew(t *testing.T) {
+	require.Equal(t, "abc-123_x.y", RequestIDOrNew("abc-123_x.y"))
+
+	for _, requestID := range []string{"", "bad id", "bad\nid", strings.Repeat("a", maxRequestIDLength+1)} {
+		newID := RequestIDOrNew(requestID)
+		require.NotEmpty(t, newID)
+		require.NotEqual(t, requestID, newID)
+	}
+}
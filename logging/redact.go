@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces the value of sensitive fields in logs
+const redacted = "[REDACTED]"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// isSensitiveKey reports whether the value of a field must never be logged:
+// passwords, tokens, secrets and one-time codes
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	switch key {
+	case "code", "recovery_code", "recovery_codes", "provisioning_uri", "authorization":
+		return true
+	}
+
+	return strings.Contains(key, "password") || strings.Contains(key, "token") || strings.Contains(key, "secret")
+}
+
+// MaskEmail keeps the first letter and the domain of an email address
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return redacted
+	}
+
+	return email[:1] + "***" + email[at:]
+}
+
+// RedactString masks the email addresses in a free text, like an error message
+func RedactString(value string) string {
+	return emailPattern.ReplaceAllStringFunc(value, MaskEmail)
+}
+
+// RedactJSON removes sensitive fields and masks email addresses in a JSON document,
+// like a task payload or a response body. Anything that is not JSON is treated as text
+func RedactJSON(data []byte) string {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return RedactString(string(data))
+	}
+
+	redactedData, err := json.Marshal(redactValue("", value))
+	if err != nil {
+		return redacted
+	}
+
+	return string(redactedData)
+}
+
+func redactValue(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, field := range v {
+			v[k] = redactValue(k, field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(key, item)
+		}
+		return v
+	case string:
+		if isSensitiveKey(key) {
+			return redacted
+		}
+		return RedactString(v)
+	default:
+		if isSensitiveKey(key) {
+			return redacted
+		}
+		return v
+	}
+}
+
+// redactAttr is the ReplaceAttr of the handlers of NewLogger, so that sensitive
+// attributes never reach the logs whatever the call site passes
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() == slog.KindGroup {
+		return attr
+	}
+
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+
+	switch value := attr.Value.Resolve(); value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactString(value.String()))
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, RedactString(err.Error()))
+		}
+	}
+
+	return attr
+}
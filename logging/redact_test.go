@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactJSON(t *testing.T) {
+	body := `{"username":"alice","password":"secret123","email":"alice@example.com","access_token":"v4.public.abc",` +
+		`"user":{"secret_code":"abcdef","full_name":"Alice"},"recovery_codes":["1234","5678"],"amount":10}`
+
+	var redactedBody map[string]any
+	require.NoError(t, json.Unmarshal([]byte(RedactJSON([]byte(body))), &redactedBody))
+
+	require.Equal(t, "alice", redactedBody["username"])
+	require.Equal(t, redacted, redactedBody["password"])
+	require.Equal(t, "a***@example.com", redactedBody["email"])
+	require.Equal(t, redacted, redactedBody["access_token"])
+	require.Equal(t, map[string]any{"secret_code": redacted, "full_name": "Alice"}, redactedBody["user"])
+	require.Equal(t, []any{redacted, redacted}, redactedBody["recovery_codes"])
+	require.Equal(t, float64(10), redactedBody["amount"])
+
+	// text is only searched for email addresses
+	require.Equal(t, "cannot send to b***@example.org: timeout", RedactJSON([]byte("cannot send to bob@example.org: timeout")))
+}
+
+func TestLoggerRedactsAttributes(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewLogger(&buffer, "production")
+
+	logger.LogAttrs(context.Background(), slog.LevelError, "process task failed",
+		slog.String("email", "alice@example.com"),
+		slog.String("refresh_token", "v4.public.abc"),
+		slog.Any("error", errors.New("failed to send email to alice@example.com")),
+		slog.Int("account_id", 1),
+	)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	require.Equal(t, "a***@example.com", record["email"])
+	require.Equal(t, redacted, record["refresh_token"])
+	require.Equal(t, float64(1), record["account_id"])
+	require.NotContains(t, buffer.String(), "alice@example.com")
+}
@@ -0,0 +1,30 @@
+package logging
+
+import (
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength bounds request ids sent by clients
+const maxRequestIDLength = 128
+
+// NewRequestID returns a random request id
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// RequestIDOrNew returns the request id sent by a client when it is safe to log,
+// or a new one otherwise
+func RequestIDOrNew(requestID string) string {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return NewRequestID()
+	}
+
+	for _, c := range requestID {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && c != '-' && c != '_' && c != '.' {
+			return NewRequestID()
+		}
+	}
+
+	return requestID
+}
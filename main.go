@@ -9,8 +9,12 @@ import (
 	"main/api"
 	"main/database/db"
 	"main/gapi"
+	"main/health"
+	"main/logging"
 	"main/mail"
+	"main/metrics"
 	"main/pb"
+	"main/tracing"
 	"main/util"
 	"main/worker"
 	"net"
@@ -18,6 +22,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -27,7 +32,13 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -35,6 +46,12 @@ import (
 //go:embed swagger/*
 var content embed.FS
 
+//go:embed web/reset_password.html
+var resetPasswordPage []byte
+
+// healthCheckInterval is how often the status of the gRPC health service is refreshed
+const healthCheckInterval = 5 * time.Second
+
 var interruptSignals = []os.Signal{
 	os.Interrupt,
 	syscall.SIGTERM,
@@ -48,18 +65,34 @@ func main() {
 		return
 	}
 
-	if cfg.Environment == "dev" {
-		var logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
-		slog.SetDefault(logger)
-	} else {
-		var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
-		slog.SetDefault(logger)
-	}
+	slog.SetDefault(logging.NewLogger(os.Stdout, cfg.Environment))
 
 	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
 	defer stop()
 
-	conn, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		slog.Error("cannot setup tracing:", slog.String("error", err.Error()))
+		return
+	}
+	defer func() {
+		// flush the spans that were not exported yet
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("failed to shutdown tracing", slog.String("error", err.Error()))
+		}
+	}()
+
+	poolConfig, err := pgxpool.ParseConfig(cfg.DatabaseURL)
+	if err != nil {
+		slog.Error("cannot parse db url:", slog.String("error", err.Error()))
+		return
+	}
+	poolConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	conn, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		slog.Error("cannot connect to db:", slog.String("error", err.Error()))
 		return
@@ -72,12 +105,35 @@ func main() {
 
 	// redis
 	redisOpt := asynq.RedisClientOpt{Addr: cfg.RedisAddress}
+
+	migrationVersion, err := health.LatestMigrationVersion(cfg.MigrationURL)
+	if err != nil {
+		slog.Error("cannot read migrations:", slog.String("error", err.Error()))
+		return
+	}
+
+	healthChecker := health.NewChecker()
+	healthChecker.AddCheck("postgres", health.PostgresCheck(conn))
+	healthChecker.AddCheck("redis", health.RedisCheck(redis.NewClient(&redis.Options{Addr: cfg.RedisAddress})))
+	healthChecker.AddCheck("migration", health.MigrationCheck(conn, migrationVersion))
+
+	prometheus.MustRegister(
+		metrics.NewPoolCollector(conn),
+		metrics.NewQueueCollector(asynq.NewInspector(redisOpt), worker.QueueCritical, worker.QueueDefault),
+	)
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
 	waitGroup, ctx := errgroup.WithContext(ctx)
-	runTaskProcessor(ctx, waitGroup, cfg, redisOpt, store)
-	runGatewayServer(ctx, waitGroup, store, cfg, taskDistributor)
-	runGrpcServer(ctx, waitGroup, store, cfg, taskDistributor)
+	runTaskProcessor(ctx, waitGroup, cfg, redisOpt, store, taskDistributor, healthChecker)
+	runTaskScheduler(ctx, waitGroup, redisOpt)
+	runGatewayServer(ctx, waitGroup, store, cfg, taskDistributor, healthChecker)
+	runGrpcServer(ctx, waitGroup, store, cfg, taskDistributor, healthChecker)
+	runMetricsServer(ctx, waitGroup, cfg)
+
+	waitGroup.Go(func() error {
+		healthChecker.Watch(ctx, healthCheckInterval)
+		return nil
+	})
 
 	err = waitGroup.Wait()
 	if err != nil {
@@ -86,9 +142,12 @@ func main() {
 	}
 }
 
-func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, cfg *util.ConfigDatabase, redisOpt asynq.RedisClientOpt, store db.Store) {
+func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, cfg *util.ConfigDatabase, redisOpt asynq.RedisClientOpt, store db.Store, taskDistributor worker.TaskDistributor, healthChecker *health.Checker) {
 	mailer := mail.NewGmailSender(cfg.EmailSenderName, cfg.EmailSenderAddress, cfg.EmailSenderPassword)
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer, taskDistributor, cfg)
+	healthChecker.AddCheck("task_processor", func(ctx context.Context) error {
+		return taskProcessor.Check()
+	})
 	slog.Info("start task processor")
 
 	err := taskProcessor.Start()
@@ -107,6 +166,26 @@ func runTaskProcessor(ctx context.Context, waitGroup *errgroup.Group, cfg *util.
 	})
 }
 
+func runTaskScheduler(ctx context.Context, waitGroup *errgroup.Group, redisOpt asynq.RedisClientOpt) {
+	taskScheduler := worker.NewRedisTaskScheduler(redisOpt)
+	slog.Info("start task scheduler")
+
+	err := taskScheduler.Start()
+	if err != nil {
+		slog.Error("Failed to start task scheduler", slog.String("error", err.Error()))
+		return
+	}
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		slog.Info("graceful shutdown task scheduler")
+		taskScheduler.Shutdown()
+		slog.Info("task scheduler is stopped")
+
+		return nil
+	})
+}
+
 func runMigration(migrationURL string, databaseURL string) {
 	migration, err := migrate.New(migrationURL, databaseURL)
 	if err != nil {
@@ -136,17 +215,20 @@ func runHttpServer(store db.Store, cfg *util.ConfigDatabase) {
 	}
 }
 
-func runGrpcServer(ctx context.Context, waitGroup *errgroup.Group, store db.Store, cfg *util.ConfigDatabase, taskDistributor worker.TaskDistributor) {
+func runGrpcServer(ctx context.Context, waitGroup *errgroup.Group, store db.Store, cfg *util.ConfigDatabase, taskDistributor worker.TaskDistributor, healthChecker *health.Checker) {
 	server, err := gapi.NewServer(store, cfg, taskDistributor)
 	if err != nil {
 		slog.Error("cannot initialize server:", slog.String("error", err.Error()))
 		return
 	}
 
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
+	// rate limits run after authentication so that users are limited by their username
+	unaryInterceptors := grpc.ChainUnaryInterceptor(gapi.GrpcMetrics, gapi.RequestIDUnaryInterceptor, gapi.GrpcLogger, gapi.ClientErrorUnaryInterceptor, server.AuthUnaryInterceptor, server.RateLimitUnaryInterceptor)
+	streamInterceptors := grpc.ChainStreamInterceptor(gapi.GrpcStreamMetrics, gapi.RequestIDStreamInterceptor, gapi.ClientErrorStreamInterceptor, server.AuthStreamInterceptor, server.RateLimitStreamInterceptor)
 
-	grpcServer := grpc.NewServer(grpcLogger)
+	grpcServer := grpc.NewServer(unaryInterceptors, streamInterceptors, grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	pb.RegisterSimpleBankServer(grpcServer, server)
+	healthpb.RegisterHealthServer(grpcServer, healthChecker.GRPCServer())
 	reflection.Register(grpcServer)
 
 	listener, err := net.Listen("tcp", cfg.GRPCServerAddress)
@@ -172,6 +254,8 @@ func runGrpcServer(ctx context.Context, waitGroup *errgroup.Group, store db.Stor
 	waitGroup.Go(func() error {
 		<-ctx.Done()
 		slog.Info("graceful shutdown gRPC server")
+		// report NOT_SERVING so that no new calls are routed here while in-flight calls finish
+		healthChecker.Shutdown()
 		grpcServer.GracefulStop()
 		slog.Info("gRPC server is stopped")
 
@@ -179,7 +263,7 @@ func runGrpcServer(ctx context.Context, waitGroup *errgroup.Group, store db.Stor
 	})
 }
 
-func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.Store, cfg *util.ConfigDatabase, taskDistributor worker.TaskDistributor) {
+func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.Store, cfg *util.ConfigDatabase, taskDistributor worker.TaskDistributor, healthChecker *health.Checker) {
 	server, err := gapi.NewServer(store, cfg, taskDistributor)
 	if err != nil {
 		slog.Error("cannot initialize server:", slog.String("error", err.Error()))
@@ -195,9 +279,35 @@ func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.S
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	// let HTTP clients see how long to back off after failed logins
+	headerOption := runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+		if key == "retry-after" {
+			return "Retry-After", true
+		}
+
+		// HttpRequestID already returns the request id
+		if key == logging.RequestIDMetadataKey {
+			return "", false
+		}
+
+		return runtime.MetadataHeaderPrefix + key, true
+	})
+
+	grpcMux := runtime.NewServeMux(
+		jsonOption,
+		headerOption,
+		runtime.WithIncomingHeaderMatcher(gapi.GatewayIncomingHeaderMatcher),
+		runtime.WithMetadata(gapi.GatewayRequestIDMetadata),
+	)
+
+	// proxy to the gRPC server instead of calling the handlers in process,
+	// so gateway requests pass the same interceptors as gRPC requests
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
 
-	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	err = pb.RegisterSimpleBankHandlerFromEndpoint(ctx, grpcMux, cfg.GRPCServerAddress, dialOptions)
 	if err != nil {
 		slog.Error("cannot register handler server:", slog.String("error", err.Error()))
 		return
@@ -206,11 +316,27 @@ func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.S
 	mux := http.NewServeMux()
 	mux.Handle("/", grpcMux)
 
+	mux.Handle("/.well-known/jwks.json", server.JWKSHandler())
+	mux.Handle("/healthz", healthChecker.LivenessHandler())
+	mux.Handle("/readyz", healthChecker.ReadinessHandler())
+
+	// the page the password reset emails link to
+	mux.HandleFunc("/reset_password", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		w.Write(resetPasswordPage)
+	})
+
 	fs := http.FileServer(http.FS(content))
 	mux.Handle("/doc/", http.StripPrefix("/doc/", fs))
 
+	handler := gapi.HttpRequestID(gapi.HttpMetrics(gapi.HttpLogger(server.HttpRateLimiter(mux))))
+	handler = otelhttp.NewHandler(handler, "gateway", otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+		return fmt.Sprintf("HTTP %s", r.Method)
+	}))
+
 	httpServer := &http.Server{
-		Handler: gapi.HttpLogger(mux),
+		Handler: handler,
 		Addr:    cfg.HTTPServerAddress,
 	}
 
@@ -222,7 +348,7 @@ func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.S
 			if errors.Is(err, http.ErrServerClosed) {
 				return nil
 			}
-			slog.Error("HTTP gateway server failed to server:", err)
+			slog.Error("HTTP gateway server failed to server:", slog.String("error", err.Error()))
 			return err
 		}
 
@@ -232,6 +358,7 @@ func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.S
 	waitGroup.Go(func() error {
 		<-ctx.Done()
 		slog.Info("graceful shutdown HTTP gateway server")
+		healthChecker.Shutdown()
 
 		err = httpServer.Shutdown(context.Background())
 		if err != nil {
@@ -243,3 +370,44 @@ func runGatewayServer(ctx context.Context, waitGroup *errgroup.Group, store db.S
 		return nil
 	})
 }
+
+// runMetricsServer serves the Prometheus metrics on their own listener, so they
+// are not reachable through the public gateway port
+func runMetricsServer(ctx context.Context, waitGroup *errgroup.Group, cfg *util.ConfigDatabase) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	metricsServer := &http.Server{
+		Handler: mux,
+		Addr:    cfg.MetricsServerAddress,
+	}
+
+	waitGroup.Go(func() error {
+		slog.Info(fmt.Sprintf("starting metrics server at %s", metricsServer.Addr))
+
+		err := metricsServer.ListenAndServe()
+		if err != nil {
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			slog.Error("metrics server failed to serve:", slog.String("error", err.Error()))
+			return err
+		}
+
+		return nil
+	})
+
+	waitGroup.Go(func() error {
+		<-ctx.Done()
+		slog.Info("graceful shutdown metrics server")
+
+		err := metricsServer.Shutdown(context.Background())
+		if err != nil {
+			slog.Error("failed to shutdown metrics server")
+			return err
+		}
+
+		slog.Info("metrics server is stopped")
+		return nil
+	})
+}
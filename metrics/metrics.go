@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+)
+
+const namespace = "simplebank"
+
+var (
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Number of gRPC calls handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Latency of gRPC calls, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP gateway requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP gateway requests, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	transfers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Number of transfers created, by currency.",
+	}, []string{"currency"})
+
+	transferVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_volume_total",
+		Help:      "Amount of money transferred, by currency.",
+	}, []string{"currency"})
+)
+
+// Handler serves the metrics of the default registry in the Prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveGrpcRequest records a handled gRPC call
+func ObserveGrpcRequest(method string, code codes.Code, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code.String()).Inc()
+	grpcRequestDuration.WithLabelValues(method, code.String()).Observe(duration.Seconds())
+}
+
+// ObserveHttpRequest records a handled HTTP request. Paths are left out, since
+// they contain ids; calls proxied to gRPC are recorded per method there
+func ObserveHttpRequest(method string, statusCode int, duration time.Duration) {
+	method = httpMethodLabel(method)
+	code := strconv.Itoa(statusCode)
+	httpRequests.WithLabelValues(method, code).Inc()
+	httpRequestDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+// httpMethodLabel returns the method for the standard HTTP methods and OTHER for
+// anything else, since clients can send any token as method
+func httpMethodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return "OTHER"
+	}
+}
+
+// ObserveTransfer records a created transfer
+func ObserveTransfer(currency string, amount int64) {
+	transfers.WithLabelValues(currency).Inc()
+	transferVolume.WithLabelValues(currency).Add(float64(amount))
+}
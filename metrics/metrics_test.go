@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestObserveTransfer(t *testing.T) {
+	before := testutil.ToFloat64(transferVolume.WithLabelValues("EUR"))
+
+	ObserveTransfer("EUR", 150)
+	ObserveTransfer("EUR", 50)
+
+	require.Equal(t, before+200, testutil.ToFloat64(transferVolume.WithLabelValues("EUR")))
+}
+
+func TestObserveGrpcRequest(t *testing.T) {
+	method := "/pb.SimpleBank/GetAccount"
+	before := testutil.ToFloat64(grpcRequests.WithLabelValues(method, codes.NotFound.String()))
+
+	ObserveGrpcRequest(method, codes.NotFound, time.Millisecond)
+
+	require.Equal(t, before+1, testutil.ToFloat64(grpcRequests.WithLabelValues(method, codes.NotFound.String())))
+}
+
+func TestObserveHttpRequest(t *testing.T) {
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "200"))
+
+	ObserveHttpRequest("GET", 200, time.Millisecond)
+
+	require.Equal(t, before+1, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "200")))
+}
+
+func TestObserveHttpRequestUnknownMethod(t *testing.T) {
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("OTHER", "405"))
+
+	ObserveHttpRequest("JUNK-"+strings.Repeat("x", 8), 405, time.Millisecond)
+	ObserveHttpRequest("get", 405, time.Millisecond)
+
+	require.Equal(t, before+2, testutil.ToFloat64(httpRequests.WithLabelValues("OTHER", "405")))
+
+}
+
+type fakeInspector map[string]*asynq.QueueInfo
+
+func (f fakeInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	info, ok := f[queue]
+	if !ok {
+		return nil, errors.New("queue not found")
+	}
+
+	return info, nil
+}
+
+func TestQueueCollector(t *testing.T) {
+	inspector := fakeInspector{
+		"critical": {Queue: "critical", Pending: 3, Retry: 1, ProcessedTotal: 10, FailedTotal: 2},
+	}
+	collector := NewQueueCollector(inspector, "critical", "default")
+
+	expected := `
+# HELP simplebank_task_queue_failed_total Number of task attempts that failed.
+# TYPE simplebank_task_queue_failed_total counter
+simplebank_task_queue_failed_total{queue="critical"} 2
+# HELP simplebank_task_queue_up Whether the queue could be inspected.
+# TYPE simplebank_task_queue_up gauge
+simplebank_task_queue_up{queue="critical"} 1
+simplebank_task_queue_up{queue="default"} 0
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"simplebank_task_queue_failed_total", "simplebank_task_queue_up")
+	require.NoError(t, err)
+
+	// the inspected queue exports five task states, latency, two counters and up,
+	// the queue that failed only up
+	require.Equal(t, 10, testutil.CollectAndCount(collector))
+}
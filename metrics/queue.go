@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// QueueInspector reads the state of asynq queues
+type QueueInspector interface {
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+}
+
+// QueueCollector exports the depth and throughput of task queues
+type QueueCollector struct {
+	inspector QueueInspector
+	queues    []string
+
+	tasks     *prometheus.Desc
+	latency   *prometheus.Desc
+	processed *prometheus.Desc
+	failed    *prometheus.Desc
+	up        *prometheus.Desc
+}
+
+// NewQueueCollector creates a new QueueCollector for the queues
+func NewQueueCollector(inspector QueueInspector, queues ...string) *QueueCollector {
+	desc := func(name string, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "task_queue", name), help, labels, nil)
+	}
+
+	return &QueueCollector{
+		inspector: inspector,
+		queues:    queues,
+		tasks:     desc("tasks", "Number of tasks in the queue, by state.", "queue", "state"),
+		latency:   desc("latency_seconds", "Age of the oldest pending task.", "queue"),
+		processed: desc("processed_total", "Number of tasks processed, including failures.", "queue"),
+		failed:    desc("failed_total", "Number of task attempts that failed.", "queue"),
+		up:        desc("up", "Whether the queue could be inspected.", "queue"),
+	}
+}
+
+func (c *QueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *QueueCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, queue := range c.queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0, queue)
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1, queue)
+
+		states := map[string]int{
+			"pending":   info.Pending,
+			"active":    info.Active,
+			"scheduled": info.Scheduled,
+			"retry":     info.Retry,
+			"archived":  info.Archived,
+		}
+		for state, count := range states {
+			ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(count), queue, state)
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.latency, prometheus.GaugeValue, info.Latency.Seconds(), queue)
+		ch <- prometheus.MustNewConstMetric(c.processed, prometheus.CounterValue, float64(info.ProcessedTotal), queue)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(info.FailedTotal), queue)
+	}
+}
@@ -0,0 +1,142 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+var (
+	// ErrInvalidPageToken is returned when a page token is malformed or was not signed by us
+	ErrInvalidPageToken = errors.New("page token is invalid")
+	// ErrPageTokenMismatch is returned when a page token was issued for a request with other parameters
+	ErrPageTokenMismatch = errors.New("page token does not match the request parameters")
+)
+
+// Cursor points at the last row of a page in (created_at, id) order.
+// Rows identified by text use Key in place of ID
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+	Key       string    `json:"key,omitempty"`
+}
+
+// pageTokenPayload is the signed content of a page token. RequestHash binds the token
+// to the parameters of the request that issued it, so it cannot be replayed with
+// other filters and skip rows (AIP-158)
+type pageTokenPayload struct {
+	Cursor
+	RequestHash string `json:"request_hash"`
+}
+
+// PageTokenMaker creates and verifies opaque, signed page tokens
+type PageTokenMaker struct {
+	secretKey []byte
+}
+
+// NewPageTokenMaker creates a new PageTokenMaker
+func NewPageTokenMaker(secretKey string) *PageTokenMaker {
+	return &PageTokenMaker{
+		secretKey: []byte(secretKey),
+	}
+}
+
+// CreateToken encodes the cursor into a signed page token, bound to the parameters
+// of the request that lists the rows. The page size is not one of them
+func (maker *PageTokenMaker) CreateToken(cursor *Cursor, requestParams ...any) (string, error) {
+	requestHash, err := hashRequestParams(requestParams)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(&pageTokenPayload{Cursor: *cursor, RequestHash: requestHash})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(data) + "." + encoding.EncodeToString(maker.sign(data)), nil
+}
+
+// VerifyToken checks the page token signature and request parameters and returns its
+// cursor. An empty token yields the cursor of the first page
+func (maker *PageTokenMaker) VerifyToken(pageToken string, requestParams ...any) (*Cursor, error) {
+	if pageToken == "" {
+		return &Cursor{}, nil
+	}
+
+	encodedData, encodedSignature, found := strings.Cut(pageToken, ".")
+	if !found {
+		return nil, ErrInvalidPageToken
+	}
+
+	encoding := base64.RawURLEncoding
+	data, err := encoding.DecodeString(encodedData)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	signature, err := encoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if !hmac.Equal(signature, maker.sign(data)) {
+		return nil, ErrInvalidPageToken
+	}
+
+	payload := &pageTokenPayload{}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	requestHash, err := hashRequestParams(requestParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal([]byte(payload.RequestHash), []byte(requestHash)) {
+		return nil, ErrPageTokenMismatch
+	}
+
+	return &payload.Cursor, nil
+}
+
+func hashRequestParams(requestParams []any) (string, error) {
+	data, err := json.Marshal(requestParams)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+func (maker *PageTokenMaker) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, maker.secretKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// PageSize returns the page size to use for a requested size,
+// applying the default for zero and capping it at MaxPageSize
+func PageSize(requested int32) int32 {
+	if requested <= 0 {
+		return DefaultPageSize
+	}
+
+	if requested > MaxPageSize {
+		return MaxPageSize
+	}
+
+	return requested
+}
@@ -0,0 +1,83 @@
+package pagination
+
+import (
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	maker := NewPageTokenMaker(util.RandomString(32))
+
+	cursor := &Cursor{
+		CreatedAt: time.Now().UTC(),
+		ID:        util.RandomInt(1, 1000),
+	}
+
+	pageToken, err := maker.CreateToken(cursor)
+	require.NoError(t, err)
+	require.NotEmpty(t, pageToken)
+
+	verifiedCursor, err := maker.VerifyToken(pageToken)
+	require.NoError(t, err)
+	require.Equal(t, cursor.ID, verifiedCursor.ID)
+	require.True(t, cursor.CreatedAt.Equal(verifiedCursor.CreatedAt))
+}
+
+func TestEmptyPageToken(t *testing.T) {
+	maker := NewPageTokenMaker(util.RandomString(32))
+
+	cursor, err := maker.VerifyToken("")
+	require.NoError(t, err)
+	require.Zero(t, cursor.ID)
+	require.True(t, cursor.CreatedAt.IsZero())
+}
+
+func TestTamperedPageToken(t *testing.T) {
+	maker := NewPageTokenMaker(util.RandomString(32))
+
+	pageToken, err := maker.CreateToken(&Cursor{CreatedAt: time.Now(), ID: 1})
+	require.NoError(t, err)
+
+	otherMaker := NewPageTokenMaker(util.RandomString(32))
+	cursor, err := otherMaker.VerifyToken(pageToken)
+	require.EqualError(t, err, ErrInvalidPageToken.Error())
+	require.Nil(t, cursor)
+
+	cursor, err = maker.VerifyToken("invalid-token")
+	require.EqualError(t, err, ErrInvalidPageToken.Error())
+	require.Nil(t, cursor)
+}
+
+func TestPageTokenRequestParams(t *testing.T) {
+	maker := NewPageTokenMaker(util.RandomString(32))
+
+	direction := "debit"
+	pageToken, err := maker.CreateToken(&Cursor{CreatedAt: time.Now(), ID: 1}, int64(1), &direction)
+	require.NoError(t, err)
+
+	cursor, err := maker.VerifyToken(pageToken, int64(1), &direction)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), cursor.ID)
+
+	otherDirection := "credit"
+	cursor, err = maker.VerifyToken(pageToken, int64(1), &otherDirection)
+	require.EqualError(t, err, ErrPageTokenMismatch.Error())
+	require.Nil(t, cursor)
+
+	cursor, err = maker.VerifyToken(pageToken, int64(2), &direction)
+	require.EqualError(t, err, ErrPageTokenMismatch.Error())
+	require.Nil(t, cursor)
+
+	cursor, err = maker.VerifyToken(pageToken, int64(1), nil)
+	require.EqualError(t, err, ErrPageTokenMismatch.Error())
+	require.Nil(t, cursor)
+}
+
+func TestPageSize(t *testing.T) {
+	require.Equal(t, int32(DefaultPageSize), PageSize(0))
+	require.Equal(t, int32(5), PageSize(5))
+	require.Equal(t, int32(MaxPageSize), PageSize(MaxPageSize+1))
+}
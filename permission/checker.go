@@ -0,0 +1,93 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"main/database/db"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL bounds how long a permission change made through another
+// server instance can take to reach this one
+const DefaultCacheTTL = 30 * time.Second
+
+// RolePermissionLister loads the permissions granted to a role
+type RolePermissionLister interface {
+	ListRolePermissions(ctx context.Context, role string) ([]*db.RolePermission, error)
+}
+
+type cacheEntry struct {
+	permissions map[string]bool
+	expiresAt   time.Time
+}
+
+// Checker tells whether a role was granted a permission. The permissions of each
+// role are cached so not every request hits the database
+type Checker struct {
+	roles RolePermissionLister
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewChecker creates a new Checker caching role permissions for ttl
+func NewChecker(roles RolePermissionLister, ttl time.Duration) *Checker {
+	return &Checker{
+		roles:   roles,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// HasPermission returns true if the role was granted the permission
+func (c *Checker) HasPermission(ctx context.Context, role string, permission string) (bool, error) {
+	permissions, err := c.permissions(ctx, role)
+	if err != nil {
+		return false, err
+	}
+
+	return permissions[permission], nil
+}
+
+// Forget drops the cached permissions of the role, so a change made through
+// this instance takes effect immediately
+func (c *Checker) Forget(role string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, role)
+}
+
+func (c *Checker) permissions(ctx context.Context, role string) (map[string]bool, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[role]
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	rolePermissions, err := c.roles.ListRolePermissions(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions of role: %w", err)
+	}
+
+	permissions := make(map[string]bool, len(rolePermissions))
+	for _, rolePermission := range rolePermissions {
+		permissions[rolePermission.Permission] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[role] = cacheEntry{
+		permissions: permissions,
+		expiresAt:   now.Add(c.ttl),
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,74 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckerCachesRolePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	rolePermissions := []*db.RolePermission{
+		{Role: util.SupportRole, Permission: util.ReadAnyAccountPermission},
+	}
+	store.EXPECT().ListRolePermissions(gomock.Any(), gomock.Eq(util.SupportRole)).Times(1).Return(rolePermissions, nil)
+
+	checker := NewChecker(store, time.Minute)
+
+	ok, err := checker.HasPermission(context.Background(), util.SupportRole, util.ReadAnyAccountPermission)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = checker.HasPermission(context.Background(), util.SupportRole, util.CreateTransferPermission)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCheckerForgetReloadsRolePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ListRolePermissions(gomock.Any(), gomock.Eq(util.SupportRole)).Times(1).Return([]*db.RolePermission{}, nil)
+
+	checker := NewChecker(store, time.Minute)
+
+	ok, err := checker.HasPermission(context.Background(), util.SupportRole, util.ManageUsersPermission)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	rolePermissions := []*db.RolePermission{
+		{Role: util.SupportRole, Permission: util.ManageUsersPermission},
+	}
+	store.EXPECT().ListRolePermissions(gomock.Any(), gomock.Eq(util.SupportRole)).Times(1).Return(rolePermissions, nil)
+
+	checker.Forget(util.SupportRole)
+
+	ok, err = checker.HasPermission(context.Background(), util.SupportRole, util.ManageUsersPermission)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestCheckerStoreError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ListRolePermissions(gomock.Any(), gomock.Any()).Times(2).Return(nil, errors.New("connection refused"))
+
+	checker := NewChecker(store, time.Minute)
+
+	// failures are not cached
+	for i := 0; i < 2; i++ {
+		ok, err := checker.HasPermission(context.Background(), util.BankerRole, util.ManageRolesPermission)
+		require.Error(t, err)
+		require.False(t, ok)
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows bursts of Requests, refilled evenly over Interval
+type Limit struct {
+	Requests int
+	Interval time.Duration
+}
+
+// Unlimited reports whether the limit lets every request through
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Interval <= 0
+}
+
+// ParseLimit parses limits written as requests/interval, e.g. 10/1m.
+// An empty string is unlimited
+func ParseLimit(s string) (Limit, error) {
+	if s == "" {
+		return Limit{}, nil
+	}
+
+	requests, interval, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, must be requests/interval", s)
+	}
+
+	limit := Limit{}
+
+	var err error
+	limit.Requests, err = strconv.Atoi(requests)
+	if err != nil || limit.Requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid requests in rate limit %q", s)
+	}
+
+	limit.Interval, err = time.ParseDuration(interval)
+	if err != nil || limit.Interval <= 0 {
+		return Limit{}, fmt.Errorf("invalid interval in rate limit %q", s)
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"main/util"
+	"path"
+	"time"
+)
+
+// Limiter applies the limit of each method to the callers of the method
+type Limiter struct {
+	store        Store
+	defaultLimit Limit
+	methodLimits map[string]Limit
+}
+
+// NewLimiter creates a new Limiter. Methods are named like their RPC, e.g. LoginUser,
+// and methods without their own limit share the default limit
+func NewLimiter(store Store, defaultLimit Limit, methodLimits map[string]Limit) *Limiter {
+	return &Limiter{
+		store:        store,
+		defaultLimit: defaultLimit,
+		methodLimits: methodLimits,
+	}
+}
+
+// NewLimiterFromConfig creates a Limiter with the store and limits of the config
+func NewLimiterFromConfig(cfg *util.ConfigDatabase) (*Limiter, error) {
+	store, err := NewStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultLimit, err := ParseLimit(cfg.RateLimitDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	methodLimits := make(map[string]Limit, len(cfg.RateLimitMethods))
+	for method, s := range cfg.RateLimitMethods {
+		methodLimits[method], err = ParseLimit(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit of method %s: %w", method, err)
+		}
+	}
+
+	return NewLimiter(store, defaultLimit, methodLimits), nil
+}
+
+// Limit returns the limit of the method, which may be a full gRPC method name
+func (l *Limiter) Limit(method string) Limit {
+	if limit, ok := l.methodLimits[path.Base(method)]; ok {
+		return limit
+	}
+
+	return l.defaultLimit
+}
+
+// Allow takes a request of the caller to the method from its bucket and returns
+// how long the caller has to wait when the limit is exceeded
+func (l *Limiter) Allow(ctx context.Context, method string, caller string) (time.Duration, error) {
+	limit := l.Limit(method)
+	if limit.Unlimited() {
+		return 0, nil
+	}
+
+	retryAfter, err := l.store.Take(ctx, path.Base(method)+":"+caller, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to take from rate limit bucket: %w", err)
+	}
+
+	return retryAfter, nil
+}
+
+// UserCaller identifies an authenticated caller
+func UserCaller(username string) string {
+	return "user:" + username
+}
+
+// IPCaller identifies an anonymous caller by its address
+func IPCaller(clientIP string) string {
+	return "ip:" + clientIP
+}
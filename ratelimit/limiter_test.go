@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected Limit
+		isValid  bool
+	}{
+		{name: "PerMinute", input: "10/1m", expected: Limit{Requests: 10, Interval: time.Minute}, isValid: true},
+		{name: "PerSecond", input: "5/1s", expected: Limit{Requests: 5, Interval: time.Second}, isValid: true},
+		{name: "Unlimited", input: "", expected: Limit{}, isValid: true},
+		{name: "MissingInterval", input: "10", isValid: false},
+		{name: "InvalidRequests", input: "ten/1m", isValid: false},
+		{name: "ZeroRequests", input: "0/1m", isValid: false},
+		{name: "InvalidInterval", input: "10/minute", isValid: false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := ParseLimit(tc.input)
+			if !tc.isValid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 3, Interval: 3 * time.Second}
+	ctx := context.Background()
+
+	for i := 0; i < limit.Requests; i++ {
+		retryAfter, err := store.Take(ctx, "key", limit)
+		require.NoError(t, err)
+		require.Zero(t, retryAfter)
+	}
+
+	// one token is refilled every second
+	retryAfter, err := store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.InDelta(t, time.Second, retryAfter, float64(10*time.Millisecond))
+
+	// other keys have their own bucket
+	retryAfter, err = store.Take(ctx, "other", limit)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestMemoryStoreRefills(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Interval: 50 * time.Millisecond}
+	ctx := context.Background()
+
+	retryAfter, err := store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	retryAfter, err = store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Positive(t, retryAfter)
+
+	time.Sleep(retryAfter)
+
+	retryAfter, err = store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+}
+
+func TestLimiterMethodLimits(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), Limit{}, map[string]Limit{
+		"LoginUser": {Requests: 1, Interval: time.Minute},
+	})
+	ctx := context.Background()
+	caller := IPCaller("10.0.0.1")
+
+	retryAfter, err := limiter.Allow(ctx, "/pb.SimpleBank/LoginUser", caller)
+	require.NoError(t, err)
+	require.Zero(t, retryAfter)
+
+	retryAfter, err = limiter.Allow(ctx, "/pb.SimpleBank/LoginUser", caller)
+	require.NoError(t, err)
+	require.Positive(t, retryAfter)
+
+	// the default limit is unlimited
+	for i := 0; i < 10; i++ {
+		retryAfter, err = limiter.Allow(ctx, "/pb.SimpleBank/GetAccount", caller)
+		require.NoError(t, err)
+		require.Zero(t, retryAfter)
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pruneInterval is how often full buckets are removed from memory
+const pruneInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	interval  time.Duration
+}
+
+// MemoryStore keeps token buckets in the memory of a single server
+type MemoryStore struct {
+	mu       sync.Mutex
+	buckets  map[string]*bucket
+	prunedAt time.Time
+}
+
+// NewMemoryStore creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  make(map[string]*bucket),
+		prunedAt: time.Now(),
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.prune(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	rate := float64(limit.Requests) / float64(limit.Interval)
+	b.tokens = min(float64(limit.Requests), b.tokens+float64(now.Sub(b.updatedAt))*rate)
+	b.updatedAt = now
+	b.interval = limit.Interval
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate), nil
+	}
+
+	b.tokens--
+	return 0, nil
+}
+
+// prune drops the buckets that have been refilled completely, they
+// behave the same as buckets that were never used
+func (s *MemoryStore) prune(now time.Time) {
+	if now.Sub(s.prunedAt) < pruneInterval {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) >= b.interval {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.prunedAt = now
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "rate_limit:"
+
+// takeScript refills and takes from the bucket atomically, using the clock of
+// Redis so that all replicas agree on the time
+var takeScript = redis.NewScript(`
+local requests = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated_at")
+local tokens = tonumber(bucket[1]) or requests
+local updatedAt = tonumber(bucket[2]) or now
+
+tokens = math.min(requests, tokens + (now - updatedAt) * requests / interval)
+
+local retryAfter = 0
+if tokens < 1 then
+	retryAfter = math.ceil((1 - tokens) * interval / requests)
+else
+	tokens = tokens - 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated_at", now)
+redis.call("PEXPIRE", KEYS[1], interval)
+
+return retryAfter
+`)
+
+// RedisStore keeps token buckets in Redis hashes shared by all replicas
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a new RedisStore
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (time.Duration, error) {
+	retryAfter, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, limit.Requests, limit.Interval.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(retryAfter) * time.Millisecond, nil
+}
@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"main/util"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	MemoryStoreType = "memory"
+	RedisStoreType  = "redis"
+)
+
+// Store keeps a token bucket per key
+type Store interface {
+	// Take removes a token from the bucket of the key. When the bucket is empty it
+	// returns how long it takes until the next token is available
+	Take(ctx context.Context, key string, limit Limit) (time.Duration, error)
+}
+
+// NewStore creates the store selected by RATE_LIMIT_STORE
+func NewStore(cfg *util.ConfigDatabase) (Store, error) {
+	switch cfg.RateLimitStore {
+	case "", MemoryStoreType:
+		return NewMemoryStore(), nil
+	case RedisStoreType:
+		return NewRedisStore(redis.NewClient(&redis.Options{Addr: cfg.RedisAddress})), nil
+	}
+
+	return nil, fmt.Errorf("unsupported rate limit store: %s", cfg.RateLimitStore)
+}
@@ -0,0 +1,132 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/database/db"
+	"main/token"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL bounds how long a password change, a role change or a disabled
+// user made through another server instance can take to invalidate existing tokens
+const DefaultCacheTTL = 30 * time.Second
+
+// cleanupThreshold is the number of cached users above which expired entries are swept
+const cleanupThreshold = 10000
+
+// ErrTokenRevoked is returned for tokens issued before the last password change of their user
+var ErrTokenRevoked = errors.New("token has been revoked by a password change")
+
+// ErrRoleChanged is returned for tokens issued before the role of their user was changed,
+// since they still carry the old role
+var ErrRoleChanged = errors.New("token has been revoked by a role change")
+
+// ErrUserDisabled is returned for tokens of disabled users
+var ErrUserDisabled = errors.New("user has been disabled")
+
+// UserGetter loads the user a token was issued for
+type UserGetter interface {
+	GetUser(ctx context.Context, username string) (*db.User, error)
+}
+
+type cacheEntry struct {
+	passwordChangedAt time.Time
+	roleChangedAt     time.Time
+	isDisabled        bool
+	expiresAt         time.Time
+}
+
+// Checker rejects tokens of disabled users and tokens issued before the password or
+// the role of their user was changed. All are cached per user so not every request hits the database
+type Checker struct {
+	users UserGetter
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewChecker creates a new Checker caching password changes for ttl
+func NewChecker(users UserGetter, ttl time.Duration) *Checker {
+	return &Checker{
+		users:   users,
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// Check returns ErrUserDisabled if the user of the token is disabled, ErrTokenRevoked
+// if the token was issued before the last password change of its user and
+// ErrRoleChanged if it was issued before the last role change
+func (c *Checker) Check(ctx context.Context, payload *token.Payload) error {
+	entry, err := c.userState(ctx, payload.Username)
+	if err != nil {
+		return err
+	}
+
+	if entry.isDisabled {
+		return ErrUserDisabled
+	}
+
+	// JWT timestamps only have second precision, so the change time is truncated
+	// to not reject tokens issued in the same second right after the change
+	if payload.IssuedAt.Before(entry.passwordChangedAt.Truncate(time.Second)) {
+		return ErrTokenRevoked
+	}
+
+	if payload.IssuedAt.Before(entry.roleChangedAt.Truncate(time.Second)) {
+		return ErrRoleChanged
+	}
+
+	return nil
+}
+
+// Forget drops the cached state of the user, so a password change, a role change
+// or a disabled user made through this instance takes effect immediately
+func (c *Checker) Forget(username string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, username)
+}
+
+func (c *Checker) userState(ctx context.Context, username string) (cacheEntry, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[username]
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	user, err := c.users.GetUser(ctx, username)
+	if err != nil {
+		return cacheEntry{}, fmt.Errorf("failed to get user of token: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= cleanupThreshold {
+		for key, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+	}
+
+	entry = cacheEntry{
+		passwordChangedAt: user.PasswordChangedAt,
+		roleChangedAt:     user.RoleChangedAt,
+		isDisabled:        user.IsDisabled,
+		expiresAt:         now.Add(c.ttl),
+	}
+	c.entries[username] = entry
+
+	return entry, nil
+}
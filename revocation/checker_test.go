@@ -0,0 +1,115 @@
+package revocation
+
+import (
+	"context"
+	"main/database/db"
+	"main/database/mockdb"
+	"main/token"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomPayload(t *testing.T, username string) *token.Payload {
+	payload, err := token.NewPayload(username, util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestCheckerCachesPasswordChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	user := &db.User{Username: util.RandomOwner()}
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+
+	checker := NewChecker(store, time.Minute)
+	payload := randomPayload(t, user.Username)
+
+	require.NoError(t, checker.Check(context.Background(), payload))
+	require.NoError(t, checker.Check(context.Background(), payload))
+}
+
+func TestCheckerRejectsTokenIssuedBeforePasswordChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	username := util.RandomOwner()
+	payload := randomPayload(t, username)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(&db.User{Username: username}, nil)
+
+	checker := NewChecker(store, time.Minute)
+	require.NoError(t, checker.Check(context.Background(), payload))
+
+	// after forgetting the user the new password change time is loaded
+	changedUser := &db.User{Username: username, PasswordChangedAt: time.Now().Add(time.Second)}
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(changedUser, nil)
+
+	checker.Forget(username)
+	require.ErrorIs(t, checker.Check(context.Background(), payload), ErrTokenRevoked)
+
+	newPayload := randomPayload(t, username)
+	newPayload.IssuedAt = changedUser.PasswordChangedAt.Add(time.Millisecond)
+	require.NoError(t, checker.Check(context.Background(), newPayload))
+}
+
+func TestCheckerUnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(nil, pgx.ErrNoRows)
+
+	checker := NewChecker(store, time.Minute)
+	err := checker.Check(context.Background(), randomPayload(t, util.RandomOwner()))
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestCheckerRejectsDisabledUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	username := util.RandomOwner()
+	payload := randomPayload(t, username)
+
+	disabledUser := &db.User{Username: username, IsDisabled: true}
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(disabledUser, nil)
+
+	checker := NewChecker(store, time.Minute)
+	require.ErrorIs(t, checker.Check(context.Background(), payload), ErrUserDisabled)
+
+	// enabling the user again makes its tokens valid once it is forgotten
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(&db.User{Username: username}, nil)
+
+	checker.Forget(username)
+	require.NoError(t, checker.Check(context.Background(), payload))
+}
+
+func TestCheckerRejectsTokenIssuedBeforeRoleChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	username := util.RandomOwner()
+	payload := randomPayload(t, username)
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(&db.User{Username: username}, nil)
+
+	checker := NewChecker(store, time.Minute)
+	require.NoError(t, checker.Check(context.Background(), payload))
+
+	// the token still carries the role the user had before the change
+	changedUser := &db.User{Username: username, RoleChangedAt: time.Now().Add(time.Second)}
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(username)).Times(1).Return(changedUser, nil)
+
+	checker.Forget(username)
+	require.ErrorIs(t, checker.Check(context.Background(), payload), ErrRoleChanged)
+
+	newPayload := randomPayload(t, username)
+	newPayload.IssuedAt = changedUser.RoleChangedAt.Add(time.Millisecond)
+	require.NoError(t, checker.Check(context.Background(), newPayload))
+}
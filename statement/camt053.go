@@ -0,0 +1,170 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+type camtDocument struct {
+	XMLName   xml.Name           `xml:"Document"`
+	Namespace string             `xml:"xmlns,attr"`
+	Statement camtBankToCustomer `xml:"BkToCstmrStmt"`
+}
+
+type camtBankToCustomer struct {
+	GroupHeader camtGroupHeader `xml:"GrpHdr"`
+	Statement   camtStatement   `xml:"Stmt"`
+}
+
+type camtGroupHeader struct {
+	MessageID       string `xml:"MsgId"`
+	CreatedDateTime string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	ID              string         `xml:"Id"`
+	CreatedDateTime string         `xml:"CreDtTm"`
+	FromToDate      camtFromToDate `xml:"FrToDt"`
+	Account         camtAccount    `xml:"Acct"`
+	Balances        []camtBalance  `xml:"Bal"`
+	Entries         []camtEntry    `xml:"Ntry"`
+}
+
+type camtFromToDate struct {
+	FromDateTime string `xml:"FrDtTm"`
+	ToDateTime   string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	ID        string `xml:"Id>Othr>Id"`
+	Currency  string `xml:"Ccy"`
+	OwnerName string `xml:"Ownr>Nm"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtBalance struct {
+	Code                 string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount               camtAmount `xml:"Amt"`
+	CreditDebitIndicator string     `xml:"CdtDbtInd"`
+	DateTime             string     `xml:"Dt>DtTm"`
+}
+
+type camtEntry struct {
+	Reference            string     `xml:"NtryRef"`
+	Amount               camtAmount `xml:"Amt"`
+	CreditDebitIndicator string     `xml:"CdtDbtInd"`
+	Status               string     `xml:"Sts"`
+	BookingDateTime      string     `xml:"BookgDt>DtTm"`
+	ValueDateTime        string     `xml:"ValDt>DtTm"`
+	BankTransactionCode  string     `xml:"BkTxCd>Prtry>Cd"`
+	TransactionID        string     `xml:"NtryDtls>TxDtls>Refs>TxId,omitempty"`
+	AdditionalEntryInfo  string     `xml:"AddtlNtryInf"`
+}
+
+// renderCAMT053 writes the statement as an ISO 20022 camt.053.001.02 bank to customer statement
+func (s *Statement) renderCAMT053(w io.Writer) error {
+	entries := make([]camtEntry, 0, len(s.Lines))
+	for _, line := range s.Lines {
+		code := "ADJUSTMENT"
+		transactionID := ""
+		if line.Transfer != nil {
+			code = "TRANSFER"
+			transactionID = strconv.FormatInt(line.Transfer.ID, 10)
+		}
+
+		entries = append(entries, camtEntry{
+			Reference:            strconv.FormatInt(line.Entry.ID, 10),
+			Amount:               s.camtAmount(line.Entry.Amount),
+			CreditDebitIndicator: creditDebitIndicator(line.Entry.Amount),
+			Status:               "BOOK",
+			BookingDateTime:      formatCAMTTime(line.Entry.CreatedAt),
+			ValueDateTime:        formatCAMTTime(line.Entry.CreatedAt),
+			BankTransactionCode:  code,
+			TransactionID:        transactionID,
+			AdditionalEntryInfo:  line.Description(),
+		})
+	}
+
+	document := camtDocument{
+		Namespace: camt053Namespace,
+		Statement: camtBankToCustomer{
+			GroupHeader: camtGroupHeader{
+				MessageID:       s.id(),
+				CreatedDateTime: formatCAMTTime(s.CreatedAt),
+			},
+			Statement: camtStatement{
+				ID:              s.id(),
+				CreatedDateTime: formatCAMTTime(s.CreatedAt),
+				FromToDate: camtFromToDate{
+					FromDateTime: formatCAMTTime(s.FromTime),
+					ToDateTime:   formatCAMTTime(s.ToTime),
+				},
+				Account: camtAccount{
+					ID:        strconv.FormatInt(s.Account.ID, 10),
+					Currency:  s.Account.Currency,
+					OwnerName: s.Account.Owner,
+				},
+				Balances: []camtBalance{
+					{
+						Code:                 "OPBD",
+						Amount:               s.camtAmount(s.OpeningBalance),
+						CreditDebitIndicator: creditDebitIndicator(s.OpeningBalance),
+						DateTime:             formatCAMTTime(s.FromTime),
+					},
+					{
+						Code:                 "CLBD",
+						Amount:               s.camtAmount(s.ClosingBalance),
+						CreditDebitIndicator: creditDebitIndicator(s.ClosingBalance),
+						DateTime:             formatCAMTTime(s.ToTime),
+					},
+				},
+				Entries: entries,
+			},
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// camtAmount returns the absolute amount; the sign is carried by the credit/debit indicator
+func (s *Statement) camtAmount(amount int64) camtAmount {
+	if amount < 0 {
+		amount = -amount
+	}
+
+	return camtAmount{
+		Currency: s.Account.Currency,
+		Value:    formatAmount(amount),
+	}
+}
+
+func creditDebitIndicator(amount int64) string {
+	if amount < 0 {
+		return "DBIT"
+	}
+
+	return "CRDT"
+}
+
+func formatCAMTTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,71 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"date",
+	"entry_id",
+	"transfer_id",
+	"counterparty_account_id",
+	"description",
+	"amount",
+	"currency",
+	"balance",
+}
+
+// renderCSV writes one row per entry, surrounded by the opening and closing balance rows
+func (s *Statement) renderCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	opening := []string{s.FromTime.UTC().Format(time.RFC3339), "", "", "", "opening balance", "", s.Account.Currency, formatAmount(s.OpeningBalance)}
+	if err := writer.Write(opening); err != nil {
+		return err
+	}
+
+	balance := s.OpeningBalance
+	for _, line := range s.Lines {
+		balance += line.Entry.Amount
+
+		transferID := ""
+		counterpartyAccountID := ""
+		if line.Transfer != nil {
+			transferID = strconv.FormatInt(line.Transfer.ID, 10)
+			counterpartyAccountID = strconv.FormatInt(line.CounterpartyAccountID(), 10)
+		}
+
+		row := []string{
+			line.Entry.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(line.Entry.ID, 10),
+			transferID,
+			counterpartyAccountID,
+			line.Description(),
+			formatAmount(line.Entry.Amount),
+			s.Account.Currency,
+			formatAmount(balance),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	closing := []string{s.ToTime.UTC().Format(time.RFC3339), "", "", "", "closing balance", "", s.Account.Currency, formatAmount(s.ClosingBalance)}
+	if err := writer.Write(closing); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatAmount(amount int64) string {
+	return strconv.FormatInt(amount, 10)
+}
@@ -0,0 +1,139 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	ofxHeader = `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+	ofxBankID = "SIMPLEBANK"
+)
+
+type ofxDocument struct {
+	XMLName xml.Name        `xml:"OFX"`
+	SignOn  ofxSignOn       `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank    ofxStatementTrn `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxStatementTrn struct {
+	TrnUID    string       `xml:"TRNUID"`
+	Status    ofxStatus    `xml:"STATUS"`
+	Statement ofxStatement `xml:"STMTRS"`
+}
+
+type ofxStatement struct {
+	CurDef       string         `xml:"CURDEF"`
+	BankAcctFrom ofxBankAccount `xml:"BANKACCTFROM"`
+	TranList     ofxTranList    `xml:"BANKTRANLIST"`
+	LedgerBal    ofxBalance     `xml:"LEDGERBAL"`
+}
+
+type ofxBankAccount struct {
+	BankID   string `xml:"BANKID"`
+	AcctID   string `xml:"ACCTID"`
+	AcctType string `xml:"ACCTTYPE"`
+}
+
+type ofxTranList struct {
+	DTStart      string           `xml:"DTSTART"`
+	DTEnd        string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	TrnType  string `xml:"TRNTYPE"`
+	DTPosted string `xml:"DTPOSTED"`
+	TrnAmt   string `xml:"TRNAMT"`
+	FITID    string `xml:"FITID"`
+	Name     string `xml:"NAME"`
+	Memo     string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+// renderOFX writes the statement as an OFX 2.2 bank statement response
+func (s *Statement) renderOFX(w io.Writer) error {
+	transactions := make([]ofxTransaction, 0, len(s.Lines))
+	for _, line := range s.Lines {
+		trnType := "CREDIT"
+		if line.Entry.Amount < 0 {
+			trnType = "DEBIT"
+		}
+
+		transaction := ofxTransaction{
+			TrnType:  trnType,
+			DTPosted: formatOFXTime(line.Entry.CreatedAt),
+			TrnAmt:   formatAmount(line.Entry.Amount),
+			FITID:    strconv.FormatInt(line.Entry.ID, 10),
+			Name:     line.Description(),
+		}
+		if line.Transfer != nil {
+			transaction.Memo = "transfer " + strconv.FormatInt(line.Transfer.ID, 10)
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	document := ofxDocument{
+		SignOn: ofxSignOn{
+			Status:   ofxStatus{Code: 0, Severity: "INFO"},
+			DTServer: formatOFXTime(s.CreatedAt),
+			Language: "ENG",
+		},
+		Bank: ofxStatementTrn{
+			TrnUID: s.id(),
+			Status: ofxStatus{Code: 0, Severity: "INFO"},
+			Statement: ofxStatement{
+				CurDef: s.Account.Currency,
+				BankAcctFrom: ofxBankAccount{
+					BankID:   ofxBankID,
+					AcctID:   strconv.FormatInt(s.Account.ID, 10),
+					AcctType: "CHECKING",
+				},
+				TranList: ofxTranList{
+					DTStart:      formatOFXTime(s.FromTime),
+					DTEnd:        formatOFXTime(s.ToTime),
+					Transactions: transactions,
+				},
+				LedgerBal: ofxBalance{
+					BalAmt: formatAmount(s.ClosingBalance),
+					DTAsOf: formatOFXTime(s.ToTime),
+				},
+			},
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header+ofxHeader); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
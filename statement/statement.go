@@ -0,0 +1,138 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"main/database/db"
+	"main/util"
+	"time"
+)
+
+// Line is a single statement line: a ledger entry and the transfer that created it, if any
+type Line struct {
+	Entry    *db.Entry
+	Transfer *db.Transfer
+}
+
+// CounterpartyAccountID returns the other account of the transfer, or 0 if the entry was not created by a transfer
+func (l *Line) CounterpartyAccountID() int64 {
+	if l.Transfer == nil {
+		return 0
+	}
+
+	if l.Transfer.FromAccountID == l.Entry.AccountID {
+		return l.Transfer.ToAccountID
+	}
+
+	return l.Transfer.FromAccountID
+}
+
+// Description returns a human readable description of the line
+func (l *Line) Description() string {
+	if l.Transfer == nil {
+		return "balance adjustment"
+	}
+
+	if l.Entry.Amount < 0 {
+		return fmt.Sprintf("transfer to account %d", l.CounterpartyAccountID())
+	}
+
+	return fmt.Sprintf("transfer from account %d", l.CounterpartyAccountID())
+}
+
+// Statement contains the entries of an account for a period with its opening and closing balances
+type Statement struct {
+	Account        *db.Account
+	FromTime       time.Time
+	ToTime         time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []*Line
+	CreatedAt      time.Time
+}
+
+// New creates a statement from the ledger entries of the period and the transfers they reference
+func New(account *db.Account, fromTime, toTime time.Time, balances *db.GetAccountStatementBalancesRow, entries []*db.Entry, transfers []*db.Transfer) *Statement {
+	transferByID := make(map[int64]*db.Transfer, len(transfers))
+	for _, transfer := range transfers {
+		transferByID[transfer.ID] = transfer
+	}
+
+	lines := make([]*Line, 0, len(entries))
+	for _, entry := range entries {
+		line := &Line{Entry: entry}
+		if entry.TransferID != nil {
+			line.Transfer = transferByID[*entry.TransferID]
+		}
+		lines = append(lines, line)
+	}
+
+	return &Statement{
+		Account:        account,
+		FromTime:       fromTime,
+		ToTime:         toTime,
+		OpeningBalance: balances.OpeningBalance,
+		ClosingBalance: balances.ClosingBalance,
+		Lines:          lines,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// TransferIDs returns the distinct transfer ids referenced by the entries
+func TransferIDs(entries []*db.Entry) []int64 {
+	seen := make(map[int64]bool)
+	ids := []int64{}
+
+	for _, entry := range entries {
+		if entry.TransferID == nil || seen[*entry.TransferID] {
+			continue
+		}
+		seen[*entry.TransferID] = true
+		ids = append(ids, *entry.TransferID)
+	}
+
+	return ids
+}
+
+// Render writes the statement to w in the given format
+func (s *Statement) Render(w io.Writer, format string) error {
+	switch format {
+	case util.CSVFormat:
+		return s.renderCSV(w)
+	case util.OFXFormat:
+		return s.renderOFX(w)
+	case util.CAMT053Format:
+		return s.renderCAMT053(w)
+	}
+
+	return fmt.Errorf("unsupported statement format: %s", format)
+}
+
+// ContentType returns the MIME type of the statement format
+func ContentType(format string) string {
+	switch format {
+	case util.CSVFormat:
+		return "text/csv"
+	case util.OFXFormat:
+		return "application/x-ofx"
+	case util.CAMT053Format:
+		return "application/xml"
+	}
+
+	return "application/octet-stream"
+}
+
+// FileName returns the suggested file name of the statement
+func (s *Statement) FileName(format string) string {
+	extension := format
+	if format == util.CAMT053Format {
+		extension = "xml"
+	}
+
+	return fmt.Sprintf("statement-%d-%s-%s.%s", s.Account.ID, s.FromTime.Format("20060102"), s.ToTime.Format("20060102"), extension)
+}
+
+// id returns a stable identifier of the statement used by the OFX and camt.053 formats
+func (s *Statement) id() string {
+	return fmt.Sprintf("%d-%d-%d", s.Account.ID, s.FromTime.Unix(), s.ToTime.Unix())
+}
@@ -0,0 +1,150 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"main/database/db"
+	"main/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatement(t *testing.T) *Statement {
+	fromTime := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	toTime := fromTime.AddDate(0, 1, 0)
+
+	account := &db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    util.RandomOwner(),
+		Currency: util.USD,
+	}
+
+	transfer := &db.Transfer{
+		ID:            util.RandomInt(1, 1000),
+		FromAccountID: account.ID,
+		ToAccountID:   account.ID + 1,
+		Amount:        30,
+		CreatedAt:     fromTime.Add(time.Hour),
+	}
+
+	entries := []*db.Entry{
+		{
+			ID:         1,
+			AccountID:  account.ID,
+			Amount:     -30,
+			CreatedAt:  transfer.CreatedAt,
+			TransferID: &transfer.ID,
+		},
+		{
+			ID:        2,
+			AccountID: account.ID,
+			Amount:    50,
+			CreatedAt: fromTime.Add(2 * time.Hour),
+		},
+	}
+
+	require.Equal(t, []int64{transfer.ID}, TransferIDs(entries))
+
+	balances := &db.GetAccountStatementBalancesRow{
+		OpeningBalance: 100,
+		ClosingBalance: 120,
+	}
+
+	return New(account, fromTime, toTime, balances, entries, []*db.Transfer{transfer})
+}
+
+func TestRenderCSV(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	err := statement.Render(&buf, util.CSVFormat)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(statement.Lines)+3)
+	require.Equal(t, csvHeader, records[0])
+
+	require.Equal(t, "opening balance", records[1][4])
+	require.Equal(t, "100", records[1][7])
+
+	require.Equal(t, "-30", records[2][5])
+	require.Equal(t, "70", records[2][7])
+	require.Equal(t, "120", records[3][7])
+
+	require.Equal(t, "closing balance", records[4][4])
+	require.Equal(t, "120", records[4][7])
+}
+
+func TestRenderOFX(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	err := statement.Render(&buf, util.OFXFormat)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(buf.String(), `OFXHEADER="200"`))
+
+	var document ofxDocument
+	err = xml.Unmarshal(buf.Bytes(), &document)
+	require.NoError(t, err)
+
+	stmt := document.Bank.Statement
+	require.Equal(t, util.USD, stmt.CurDef)
+	require.Equal(t, "120", stmt.LedgerBal.BalAmt)
+	require.Len(t, stmt.TranList.Transactions, 2)
+	require.Equal(t, "DEBIT", stmt.TranList.Transactions[0].TrnType)
+	require.Equal(t, "CREDIT", stmt.TranList.Transactions[1].TrnType)
+}
+
+func TestRenderCAMT053(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	err := statement.Render(&buf, util.CAMT053Format)
+	require.NoError(t, err)
+
+	var document camtDocument
+	err = xml.Unmarshal(buf.Bytes(), &document)
+	require.NoError(t, err)
+
+	stmt := document.Statement.Statement
+	require.Len(t, stmt.Balances, 2)
+	require.Equal(t, "OPBD", stmt.Balances[0].Code)
+	require.Equal(t, "100", stmt.Balances[0].Amount.Value)
+	require.Equal(t, "CLBD", stmt.Balances[1].Code)
+	require.Equal(t, "120", stmt.Balances[1].Amount.Value)
+
+	require.Len(t, stmt.Entries, 2)
+	require.Equal(t, "DBIT", stmt.Entries[0].CreditDebitIndicator)
+	require.Equal(t, "30", stmt.Entries[0].Amount.Value)
+	require.Equal(t, "TRANSFER", stmt.Entries[0].BankTransactionCode)
+	require.Equal(t, "CRDT", stmt.Entries[1].CreditDebitIndicator)
+	require.Equal(t, "ADJUSTMENT", stmt.Entries[1].BankTransactionCode)
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	statement := randomStatement(t)
+
+	var buf bytes.Buffer
+	err := statement.Render(&buf, "pdf")
+	require.Error(t, err)
+}
+
+func TestRenderSummary(t *testing.T) {
+	statement := randomStatement(t)
+	fullName := util.RandomOwner()
+
+	var buf bytes.Buffer
+	err := statement.RenderSummary(&buf, fullName)
+	require.NoError(t, err)
+
+	summary := buf.String()
+	require.True(t, strings.Contains(summary, fullName))
+	require.True(t, strings.Contains(summary, "<td>50 USD</td>"))
+	require.True(t, strings.Contains(summary, "<td>30 USD</td>"))
+	require.True(t, strings.Contains(summary, "<td>120 USD</td>"))
+}
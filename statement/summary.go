@@ -0,0 +1,44 @@
+package statement
+
+import (
+	"html/template"
+	"io"
+)
+
+var summaryTemplate = template.Must(template.New("summary").Parse(`
+	<h1>Hello {{.FullName}}</h1>
+	<p>Here is your statement for account #{{.Account.ID}} from {{.FromTime.Format "2006-01-02"}} to {{.ToTime.Format "2006-01-02"}}.</p>
+	<table>
+		<tr><td>Opening balance</td><td>{{.OpeningBalance}} {{.Account.Currency}}</td></tr>
+		<tr><td>Money in</td><td>{{.MoneyIn}} {{.Account.Currency}}</td></tr>
+		<tr><td>Money out</td><td>{{.MoneyOut}} {{.Account.Currency}}</td></tr>
+		<tr><td>Closing balance</td><td>{{.ClosingBalance}} {{.Account.Currency}}</td></tr>
+	</table>
+	<p>{{len .Lines}} entries are listed in the attached file.</p>
+	<p>You can stop receiving monthly statements by updating your preferences.</p>
+	`))
+
+type summaryData struct {
+	*Statement
+	FullName string
+	MoneyIn  int64
+	MoneyOut int64
+}
+
+// RenderSummary writes an HTML summary of the statement suitable for an email body
+func (s *Statement) RenderSummary(w io.Writer, fullName string) error {
+	data := summaryData{
+		Statement: s,
+		FullName:  fullName,
+	}
+
+	for _, line := range s.Lines {
+		if line.Entry.Amount < 0 {
+			data.MoneyOut -= line.Entry.Amount
+		} else {
+			data.MoneyIn += line.Entry.Amount
+		}
+	}
+
+	return summaryTemplate.Execute(w, data)
+}
@@ -0,0 +1,131 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sort"
+)
+
+// JSONWebKey is the public part of a token signing key as defined by RFC 7517
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet is the document served at /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeySetPublisher is implemented by makers whose tokens can be verified with published public keys
+type KeySetPublisher interface {
+	KeySet() *JSONWebKeySet
+}
+
+// KeySet returns the public keys that verify the tokens of an asymmetric maker.
+// Symmetric makers have no public keys and return an empty set
+func (maker *JWTMaker) KeySet() *JSONWebKeySet {
+	keySet := &JSONWebKeySet{Keys: []JSONWebKey{}}
+
+	for keyID, publicKey := range maker.verificationKeys {
+		keySet.Keys = append(keySet.Keys, newJSONWebKey(keyID, publicKey))
+	}
+
+	sort.Slice(keySet.Keys, func(i, j int) bool {
+		return keySet.Keys[i].KeyID < keySet.Keys[j].KeyID
+	})
+
+	return keySet
+}
+
+func newJSONWebKey(keyID string, publicKey crypto.PublicKey) JSONWebKey {
+	encoding := base64.RawURLEncoding
+
+	jwk := JSONWebKey{
+		KeyID:     keyID,
+		Use:       "sig",
+		Algorithm: keyAlgorithm(publicKey),
+	}
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encoding.EncodeToString(key.N.Bytes())
+		jwk.E = encoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType = "EC"
+		jwk.Curve = key.Curve.Params().Name
+		jwk.X = encoding.EncodeToString(key.X.FillBytes(make([]byte, size)))
+		jwk.Y = encoding.EncodeToString(key.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encoding.EncodeToString(key)
+	}
+
+	return jwk
+}
+
+// parsePrivateKeyPEM parses a PKCS #8, PKCS #1 RSA or SEC 1 EC private key
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var key any
+	var err error
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok || keyAlgorithm(signer.Public()) == "" {
+		return nil, errors.New("unsupported private key type")
+	}
+
+	return signer, nil
+}
+
+// parsePublicKeyPEM parses a PKIX public key
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyAlgorithm(key) == "" {
+		return nil, errors.New("unsupported public key type")
+	}
+
+	return key, nil
+}
@@ -1,16 +1,35 @@
 package token
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// Asymmetric JWT signing algorithms
+const (
+	RS256 = "RS256"
+	ES256 = "ES256"
+	EdDSA = "EdDSA"
+)
+
 // JWTMaker is a JSON Web Token maker
 type JWTMaker struct {
 	secretKey string
+
+	// set for asymmetric algorithms only
+	signingMethod    jwt.SigningMethod
+	signingKeyID     string
+	signingKey       crypto.Signer
+	verificationKeys map[string]crypto.PublicKey
 }
 
 type SignedDetails struct {
@@ -30,14 +49,74 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 	}, nil
 }
 
+// NewAsymmetricJWTMaker creates a new JWTMaker signing with the PEM encoded private key
+// using RS256, ES256 or EdDSA and a kid header. Tokens are verified with the public key
+// of the signing key or any of the PEM encoded verification keys, indexed by key id.
+// Without a signing key the maker can only verify tokens
+func NewAsymmetricJWTMaker(algorithm string, signingKeyID string, signingKeyPEM []byte, verificationKeysPEM map[string][]byte) (Maker, error) {
+	if algorithm != RS256 && algorithm != ES256 && algorithm != EdDSA {
+		return nil, fmt.Errorf("unsupported algorithm %s: must be one of %s, %s or %s", algorithm, RS256, ES256, EdDSA)
+	}
+
+	maker := &JWTMaker{
+		signingMethod:    jwt.GetSigningMethod(algorithm),
+		signingKeyID:     signingKeyID,
+		verificationKeys: make(map[string]crypto.PublicKey, len(verificationKeysPEM)+1),
+	}
+
+	for keyID, publicKeyPEM := range verificationKeysPEM {
+		publicKey, err := parsePublicKeyPEM(publicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key %s: %w", keyID, err)
+		}
+
+		maker.verificationKeys[keyID] = publicKey
+	}
+
+	if len(signingKeyPEM) > 0 {
+		if signingKeyID == "" {
+			return nil, errors.New("signing key id must be provided")
+		}
+
+		signingKey, err := parsePrivateKeyPEM(signingKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key: %w", err)
+		}
+
+		if keyAlgorithm(signingKey.Public()) != algorithm {
+			return nil, fmt.Errorf("signing key cannot be used with %s", algorithm)
+		}
+
+		maker.signingKey = signingKey
+		maker.verificationKeys[signingKeyID] = signingKey.Public()
+	}
+
+	if len(maker.verificationKeys) == 0 {
+		return nil, errors.New("at least one signing or verification key must be provided")
+	}
+
+	return maker, nil
+}
+
 func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
 	payload, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", nil, err
 	}
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payloadToClaims(payload))
-	token, err := jwtToken.SignedString([]byte(maker.secretKey))
+	if maker.signingMethod == nil {
+		jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payloadToClaims(payload))
+		token, err := jwtToken.SignedString([]byte(maker.secretKey))
+		return token, payload, err
+	}
+
+	if maker.signingKey == nil {
+		return "", nil, ErrNoSigningKey
+	}
+
+	jwtToken := jwt.NewWithClaims(maker.signingMethod, payloadToClaims(payload))
+	jwtToken.Header["kid"] = maker.signingKeyID
+	token, err := jwtToken.SignedString(maker.signingKey)
 	return token, payload, err
 }
 
@@ -51,7 +130,14 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 		return []byte(maker.secretKey), nil
 	}
 
-	jwtToken, err := jwt.ParseWithClaims(token, &SignedDetails{}, keyFunc)
+	validMethods := []string{jwt.SigningMethodHS256.Alg()}
+
+	if maker.signingMethod != nil {
+		keyFunc = maker.verificationKey
+		validMethods = []string{RS256, ES256, EdDSA}
+	}
+
+	jwtToken, err := jwt.ParseWithClaims(token, &SignedDetails{}, keyFunc, jwt.WithValidMethods(validMethods))
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, ErrExpiredToken
@@ -75,6 +161,42 @@ func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
 	return payload, nil
 }
 
+// verificationKey finds the public key named by the kid header and makes
+// sure the token was signed with the algorithm of that key
+func (maker *JWTMaker) verificationKey(t *jwt.Token) (interface{}, error) {
+	keyID, ok := t.Header["kid"].(string)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := maker.verificationKeys[keyID]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	if keyAlgorithm(publicKey) != t.Method.Alg() {
+		return nil, ErrInvalidToken
+	}
+
+	return publicKey, nil
+}
+
+// keyAlgorithm returns the JWT algorithm used with the public key
+func keyAlgorithm(publicKey crypto.PublicKey) string {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return RS256
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P256() {
+			return ES256
+		}
+	case ed25519.PublicKey:
+		return EdDSA
+	}
+
+	return ""
+}
+
 func payloadToClaims(payload *Payload) *SignedDetails {
 	return &SignedDetails{
 		ID:       payload.ID,
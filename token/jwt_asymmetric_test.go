@@ -0,0 +1,151 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"main/util"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func randomKeyPEM(t *testing.T, algorithm string) (privateKeyPEM []byte, publicKeyPEM []byte) {
+	var privateKey crypto.Signer
+	var err error
+
+	switch algorithm {
+	case RS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case ES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case EdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+
+	privateKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	publicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	return privateKeyPEM, publicKeyPEM
+}
+
+func TestAsymmetricJWTMaker(t *testing.T) {
+	for _, algorithm := range []string{RS256, ES256, EdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			privateKeyPEM, _ := randomKeyPEM(t, algorithm)
+			jwtMaker, err := NewAsymmetricJWTMaker(algorithm, "key-1", privateKeyPEM, nil)
+			require.NoError(t, err)
+
+			username := util.RandomOwner()
+			role := util.DepositorRole
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, payload, err := jwtMaker.CreateToken(username, role, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+			require.NotEmpty(t, payload)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &SignedDetails{})
+			require.NoError(t, err)
+			require.Equal(t, algorithm, parsed.Method.Alg())
+			require.Equal(t, "key-1", parsed.Header["kid"])
+
+			payload, err = jwtMaker.VerifyToken(token)
+			require.NoError(t, err)
+			require.Equal(t, role, payload.Role)
+			require.Equal(t, username, payload.Username)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+			keySet := jwtMaker.(KeySetPublisher).KeySet()
+			require.Len(t, keySet.Keys, 1)
+			require.Equal(t, algorithm, keySet.Keys[0].Algorithm)
+		})
+	}
+}
+
+func TestAsymmetricJWTKeyRotation(t *testing.T) {
+	oldPrivateKeyPEM, oldPublicKeyPEM := randomKeyPEM(t, RS256)
+	oldMaker, err := NewAsymmetricJWTMaker(RS256, "key-1", oldPrivateKeyPEM, nil)
+	require.NoError(t, err)
+
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// rotating may also switch the algorithm, the old key keeps verifying its own tokens
+	newPrivateKeyPEM, newPublicKeyPEM := randomKeyPEM(t, EdDSA)
+	newMaker, err := NewAsymmetricJWTMaker(EdDSA, "key-2", newPrivateKeyPEM, map[string][]byte{"key-1": oldPublicKeyPEM})
+	require.NoError(t, err)
+
+	_, err = newMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := newMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	_, err = oldMaker.VerifyToken(newToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	require.Len(t, newMaker.(KeySetPublisher).KeySet().Keys, 2)
+
+	verifier, err := NewAsymmetricJWTMaker(EdDSA, "", nil, map[string][]byte{"key-2": newPublicKeyPEM})
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestInvalidAsymmetricJWTToken(t *testing.T) {
+	privateKeyPEM, publicKeyPEM := randomKeyPEM(t, RS256)
+	jwtMaker, err := NewAsymmetricJWTMaker(RS256, "key-1", privateKeyPEM, nil)
+	require.NoError(t, err)
+
+	payload, err := NewPayload(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// HS256 signed with the public key must not be accepted
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payloadToClaims(payload))
+	jwtToken.Header["kid"] = "key-1"
+	token, err := jwtToken.SignedString(publicKeyPEM)
+	require.NoError(t, err)
+
+	_, err = jwtMaker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	// unknown key id
+	otherPrivateKeyPEM, _ := randomKeyPEM(t, RS256)
+	otherMaker, err := NewAsymmetricJWTMaker(RS256, "key-2", otherPrivateKeyPEM, nil)
+	require.NoError(t, err)
+
+	token, _, err = otherMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	_, err = jwtMaker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	// key and algorithm must match
+	_, err = NewAsymmetricJWTMaker(ES256, "key-1", privateKeyPEM, nil)
+	require.Error(t, err)
+
+	_, err = NewAsymmetricJWTMaker("HS256", "key-1", privateKeyPEM, nil)
+	require.Error(t, err)
+}
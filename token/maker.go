@@ -1,6 +1,11 @@
 package token
 
-import "time"
+import (
+	"fmt"
+	"main/util"
+	"os"
+	"time"
+)
 
 // Maker is an interface for managing tokens
 type Maker interface {
@@ -10,3 +15,42 @@ type Maker interface {
 	// VerifyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
+
+// NewMaker creates the token maker selected by the config: an asymmetric JWT maker
+// when a JWT algorithm is configured, PASETO v4.public when a signing or
+// verification key is configured, PASETO v4.local otherwise
+func NewMaker(cfg *util.ConfigDatabase) (Maker, error) {
+	if cfg.JWTAlgorithm != "" {
+		return newAsymmetricJWTMakerFromFiles(cfg)
+	}
+
+	if cfg.TokenSigningKey != "" || len(cfg.TokenVerificationKeys) > 0 {
+		return NewPASETOPublicMaker(cfg.TokenSigningKeyID, cfg.TokenSigningKey, cfg.TokenVerificationKeys)
+	}
+
+	return NewPASETOMaker(cfg.SecretKey)
+}
+
+func newAsymmetricJWTMakerFromFiles(cfg *util.ConfigDatabase) (Maker, error) {
+	var signingKeyPEM []byte
+	if cfg.JWTSigningKeyFile != "" {
+		data, err := os.ReadFile(cfg.JWTSigningKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read signing key: %w", err)
+		}
+
+		signingKeyPEM = data
+	}
+
+	verificationKeysPEM := make(map[string][]byte, len(cfg.JWTVerificationKeyFiles))
+	for keyID, path := range cfg.JWTVerificationKeyFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read verification key %s: %w", keyID, err)
+		}
+
+		verificationKeysPEM[keyID] = data
+	}
+
+	return NewAsymmetricJWTMaker(cfg.JWTAlgorithm, cfg.TokenSigningKeyID, signingKeyPEM, verificationKeysPEM)
+}
@@ -0,0 +1,130 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// ErrNoSigningKey is returned when a verify-only maker is asked to create a token
+var ErrNoSigningKey = errors.New("token maker has no signing key")
+
+// footer is stored unencrypted next to the signed claims and tells
+// verifiers which key signed the token
+type footer struct {
+	KeyID string `json:"kid"`
+}
+
+// PasetoPublicMaker is a PASETO v4.public token maker. Tokens are signed
+// with one Ed25519 key and verified with any of the known public keys, so
+// keys can be rotated without invalidating tokens signed by the previous key
+type PasetoPublicMaker struct {
+	signingKeyID     string
+	signingKey       *paseto.V4AsymmetricSecretKey
+	verificationKeys map[string]paseto.V4AsymmetricPublicKey
+}
+
+// NewPASETOPublicMaker creates a new PasetoPublicMaker from a hex encoded
+// Ed25519 seed and hex encoded public verification keys indexed by key id.
+// The public key of the signing key is always accepted. Without a signing
+// key the maker can only verify tokens
+func NewPASETOPublicMaker(signingKeyID string, signingKeyHex string, verificationKeysHex map[string]string) (Maker, error) {
+	maker := &PasetoPublicMaker{
+		signingKeyID:     signingKeyID,
+		verificationKeys: make(map[string]paseto.V4AsymmetricPublicKey, len(verificationKeysHex)+1),
+	}
+
+	for keyID, publicKeyHex := range verificationKeysHex {
+		publicKey, err := paseto.NewV4AsymmetricPublicKeyFromHex(publicKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key %s: %w", keyID, err)
+		}
+
+		maker.verificationKeys[keyID] = publicKey
+	}
+
+	if signingKeyHex != "" {
+		if signingKeyID == "" {
+			return nil, errors.New("signing key id must be provided")
+		}
+
+		signingKey, err := paseto.NewV4AsymmetricSecretKeyFromSeed(signingKeyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key: %w", err)
+		}
+
+		maker.signingKey = &signingKey
+		maker.verificationKeys[signingKeyID] = signingKey.Public()
+	}
+
+	if len(maker.verificationKeys) == 0 {
+		return nil, errors.New("at least one signing or verification key must be provided")
+	}
+
+	return maker, nil
+}
+
+func (maker *PasetoPublicMaker) CreateToken(username string, role string, duration time.Duration) (string, *Payload, error) {
+	if maker.signingKey == nil {
+		return "", nil, ErrNoSigningKey
+	}
+
+	payload, err := NewPayload(username, role, duration)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tokenFooter, err := json.Marshal(footer{KeyID: maker.signingKeyID})
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := paseto.NewToken()
+	token.Set("id", payload.ID)
+	token.Set("username", payload.Username)
+	token.Set("role", payload.Role)
+	token.SetIssuedAt(payload.IssuedAt)
+	token.SetExpiration(payload.ExpiredAt)
+	token.SetFooter(tokenFooter)
+
+	return token.V4Sign(*maker.signingKey, nil), payload, nil
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(token string) (*Payload, error) {
+	parser := paseto.NewParser()
+
+	// the footer is authenticated by the signature, which is checked below with the key it names
+	rawFooter, err := parser.UnsafeParseFooter(paseto.V4Public, token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var tokenFooter footer
+	if err := json.Unmarshal(rawFooter, &tokenFooter); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	publicKey, ok := maker.verificationKeys[tokenFooter.KeyID]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	newToken, err := parser.ParseV4Public(publicKey, token, nil)
+	if err != nil {
+		if err.Error() == "this token has expired" {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{}
+	err = json.Unmarshal(newToken.ClaimsJSON(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
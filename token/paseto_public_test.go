@@ -0,0 +1,130 @@
+package token
+
+import (
+	"encoding/hex"
+	"main/util"
+	"strings"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+	"github.com/stretchr/testify/require"
+)
+
+func randomSigningKey() (seedHex string, publicKeyHex string) {
+	secretKey := paseto.NewV4AsymmetricSecretKey()
+	return secretKey.ExportSeedHex(), secretKey.Public().ExportHex()
+}
+
+func TestPASETOPublicMaker(t *testing.T) {
+	seed, _ := randomSigningKey()
+	maker, err := NewPASETOPublicMaker("key-1", seed, nil)
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	role := util.DepositorRole
+	duration := time.Minute
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(duration)
+
+	token, payload, err := maker.CreateToken(username, role, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+	require.True(t, strings.HasPrefix(token, "v4.public."))
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	require.Equal(t, role, payload.Role)
+	require.NotZero(t, payload.ID)
+	require.Equal(t, username, payload.Username)
+	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+}
+
+func TestExpiredPASETOPublicToken(t *testing.T) {
+	seed, _ := randomSigningKey()
+	maker, err := NewPASETOPublicMaker("key-1", seed, nil)
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.Error(t, err)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestPASETOPublicKeyRotation(t *testing.T) {
+	oldSeed, oldPublicKey := randomSigningKey()
+	oldMaker, err := NewPASETOPublicMaker("key-1", oldSeed, nil)
+	require.NoError(t, err)
+
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// the new signing key takes over while the old public key stays trusted
+	newSeed, newPublicKey := randomSigningKey()
+	newMaker, err := NewPASETOPublicMaker("key-2", newSeed, map[string]string{"key-1": oldPublicKey})
+	require.NoError(t, err)
+
+	_, err = newMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := newMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	_, err = oldMaker.VerifyToken(newToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	// downstream services only need the public keys
+	verifier, err := NewPASETOPublicMaker("", "", map[string]string{"key-1": oldPublicKey, "key-2": newPublicKey})
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyToken(oldToken)
+	require.NoError(t, err)
+	_, err = verifier.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	_, _, err = verifier.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestInvalidPASETOPublicToken(t *testing.T) {
+	seed, publicKey := randomSigningKey()
+	maker, err := NewPASETOPublicMaker("key-1", seed, nil)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	// a token claiming another key id is rejected
+	otherSeed, _ := randomSigningKey()
+	otherMaker, err := NewPASETOPublicMaker("key-1", otherSeed, nil)
+	require.NoError(t, err)
+
+	_, err = otherMaker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	// a symmetric token is not accepted
+	localMaker, err := NewPASETOMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	localToken, _, err := localMaker.CreateToken(util.RandomOwner(), util.DepositorRole, time.Minute)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(localToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+
+	_, err = NewPASETOPublicMaker("key-1", hex.EncodeToString([]byte("short")), nil)
+	require.Error(t, err)
+
+	_, err = NewPASETOPublicMaker("", seed, map[string]string{"key-1": publicKey})
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix marks secrets encrypted by a Cipher. Base32 secrets stored
+// before encryption at rest never contain a colon
+const encryptedPrefix = "v1:"
+
+var ErrInvalidCiphertext = errors.New("invalid encrypted totp secret")
+
+// Cipher encrypts TOTP secrets at rest with AES-256-GCM
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a Cipher from a 32 byte key
+func NewCipher(key string) (*Cipher, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid key size: must be exactly 32 characters")
+	}
+
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt encrypts the secret of the user. The username is authenticated along with it,
+// so a secret copied to another user's row does not decrypt
+func (c *Cipher) Encrypt(username, secret string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(secret), []byte(username))
+	return encryptedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the secret of the user. Secrets stored in plaintext before
+// encryption at rest are returned as they are
+func (c *Cipher) Decrypt(username, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, encryptedPrefix)
+	if !ok {
+		return stored, nil
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	secret, err := c.aead.Open(nil, nonce, ciphertext, []byte(username))
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(secret), nil
+}
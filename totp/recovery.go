@@ -0,0 +1,48 @@
+package totp
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+const (
+	RecoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// no 0/O or 1/I/L so codes can be read back without ambiguity
+	recoveryCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+)
+
+// GenerateRecoveryCodes creates one-time codes that can be used instead of a TOTP code
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		random := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+
+		var sb strings.Builder
+		for j, b := range random {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+
+		codes = append(codes, sb.String())
+	}
+
+	return codes, nil
+}
+
+// NormalizeRecoveryCode makes user input comparable with generated codes
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) == recoveryCodeLength && !strings.Contains(code, "-") {
+		code = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+	}
+
+	return code
+}
@@ -0,0 +1,120 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	// SHA1 test vectors from RFC 6238 appendix B, truncated to 6 digits
+	secret := encoding.EncodeToString([]byte("12345678901234567890"))
+
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := GenerateCode(secret, time.Unix(tc.unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, tc.code, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := Validate(code, secret, now, 0)
+	require.True(t, ok)
+	require.Equal(t, now.Unix()/int64(Period.Seconds()), step)
+
+	_, ok = Validate(code, secret, now.Add(Period), 0)
+	require.True(t, ok)
+
+	_, ok = Validate(code, secret, now.Add(3*Period), 0)
+	require.False(t, ok)
+
+	_, ok = Validate("12345", secret, now, 0)
+	require.False(t, ok)
+
+	_, ok = Validate(code, "invalid secret!", now, 0)
+	require.False(t, ok)
+
+	// an accepted code cannot be replayed within its window
+	_, ok = Validate(code, secret, now, step)
+	require.False(t, ok)
+
+	_, ok = Validate(code, secret, now.Add(Period), step)
+	require.False(t, ok)
+}
+
+func TestCipher(t *testing.T) {
+	cipher, err := NewCipher("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt("alice", secret)
+	require.NoError(t, err)
+	require.NotContains(t, encrypted, secret)
+
+	decrypted, err := cipher.Decrypt("alice", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+
+	// the secret is bound to its user
+	_, err = cipher.Decrypt("bob", encrypted)
+	require.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	// secrets stored before encryption at rest are still readable
+	decrypted, err = cipher.Decrypt("alice", secret)
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+
+	_, err = NewCipher("short")
+	require.Error(t, err)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+
+	uri, err := url.Parse(ProvisioningURI("Simple Bank", "alice", secret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Simple Bank:alice", uri.Path)
+	require.Equal(t, secret, uri.Query().Get("secret"))
+	require.Equal(t, "Simple Bank", uri.Query().Get("issuer"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		require.Len(t, code, recoveryCodeLength+1)
+		require.False(t, seen[code])
+		seen[code] = true
+
+		require.Equal(t, code, NormalizeRecoveryCode(strings.ToLower(strings.ReplaceAll(code, "-", ""))))
+	}
+}
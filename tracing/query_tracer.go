@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const sqlcNamePrefix = "-- name: "
+
+// QueryTracer creates a span for every query run on a pgx connection.
+// Arguments are left out of the spans, since they hold user data
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+// NewQueryTracer creates a new QueryTracer
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{
+		tracer: Tracer("main/database/db"),
+	}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryName(data.SQL)
+
+	ctx, _ = t.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// queryName returns the name sqlc gives a query, or the SQL command for other statements
+func queryName(sql string) string {
+	sql = strings.TrimSpace(sql)
+
+	if strings.HasPrefix(sql, sqlcNamePrefix) {
+		fields := strings.Fields(strings.TrimPrefix(sql, sqlcNamePrefix))
+		if len(fields) > 0 {
+			return fields[0]
+		}
+	}
+
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	return strings.ToUpper(fields[0])
+}
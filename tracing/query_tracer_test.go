@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestQueryName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sql      string
+		expected string
+	}{
+		{name: "Sqlc", sql: "-- name: GetUser :one\nSELECT * FROM users WHERE username = $1", expected: "GetUser"},
+		{name: "Plain", sql: "  select 1", expected: "SELECT"},
+		{name: "Empty", sql: "", expected: "query"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, queryName(tc.sql))
+		})
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	tracer := NewQueryTracer()
+	sql := "-- name: GetUser :one\nSELECT * FROM users WHERE username = $1"
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{"secret"}})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("connection reset")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "GetUser", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	for _, attr := range spans[0].Attributes() {
+		require.NotContains(t, attr.Value.Emit(), "secret")
+	}
+
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -7,18 +7,41 @@ import (
 )
 
 type ConfigDatabase struct {
-	Environment         string        `env:"ENVIRONMENT" env-required:"true"`
-	DatabaseURL         string        `env:"DATABASE_URL" env-required:"true"`
-	MigrationURL        string        `env:"MIGRATION_URL" env-required:"true"`
-	RedisAddress        string        `env:"REDIS_ADDRESS" env-required:"true"`
-	HTTPServerAddress   string        `env:"HTTP_SERVER_ADDR" env-required:"true"`
-	GRPCServerAddress   string        `env:"GRPC_SERVER_ADDR" env-required:"true"`
-	SecretKey           string        `env:"SECRET_KEY" env-required:"true"`
-	TokenDuration       time.Duration `env:"TOKEN_DURATION" env-required:"true"`
-	RefreshDuration     time.Duration `env:"REFRESH_DURATION" env-required:"true"`
-	EmailSenderName     string        `env:"EMAIL_SENDER_NAME" env-required:"true"`
-	EmailSenderAddress  string        `env:"EMAIL_SENDER_ADDRESS" env-required:"true"`
-	EmailSenderPassword string        `env:"EMAIL_SENDER_PASSWORD" env-required:"true"`
+	Environment             string            `env:"ENVIRONMENT" env-required:"true"`
+	DatabaseURL             string            `env:"DATABASE_URL" env-required:"true"`
+	MigrationURL            string            `env:"MIGRATION_URL" env-required:"true"`
+	RedisAddress            string            `env:"REDIS_ADDRESS" env-required:"true"`
+	HTTPServerAddress       string            `env:"HTTP_SERVER_ADDR" env-required:"true"`
+	GRPCServerAddress       string            `env:"GRPC_SERVER_ADDR" env-required:"true"`
+	MetricsServerAddress    string            `env:"METRICS_SERVER_ADDR" env-default:":3002"`
+	TrustedProxies          []string          `env:"TRUSTED_PROXIES" env-separator:","`
+	AppBaseURL              string            `env:"APP_BASE_URL" env-default:"http://localhost:3000"`
+	SecretKey               string            `env:"SECRET_KEY" env-required:"true"`
+	TokenSigningKeyID       string            `env:"TOKEN_SIGNING_KEY_ID"`
+	TokenSigningKey         string            `env:"TOKEN_SIGNING_KEY"`
+	TokenVerificationKeys   map[string]string `env:"TOKEN_VERIFICATION_KEYS"`
+	JWTAlgorithm            string            `env:"JWT_ALGORITHM"`
+	JWTSigningKeyFile       string            `env:"JWT_SIGNING_KEY_FILE"`
+	JWTVerificationKeyFiles map[string]string `env:"JWT_VERIFICATION_KEY_FILES"`
+	TOTPEncryptionKey       string            `env:"TOTP_ENCRYPTION_KEY"`
+	TokenDuration           time.Duration     `env:"TOKEN_DURATION" env-required:"true"`
+	RefreshDuration         time.Duration     `env:"REFRESH_DURATION" env-required:"true"`
+	EmailSenderName         string            `env:"EMAIL_SENDER_NAME" env-required:"true"`
+	EmailSenderAddress      string            `env:"EMAIL_SENDER_ADDRESS" env-required:"true"`
+	EmailSenderPassword     string            `env:"EMAIL_SENDER_PASSWORD" env-required:"true"`
+	LoginLockoutStore       string            `env:"LOGIN_LOCKOUT_STORE" env-default:"postgres"`
+	LoginBackoffAfter       int               `env:"LOGIN_BACKOFF_AFTER" env-default:"3"`
+	LoginBackoffDelay       time.Duration     `env:"LOGIN_BACKOFF_DELAY" env-default:"1s"`
+	LoginLockoutAfter       int               `env:"LOGIN_LOCKOUT_AFTER" env-default:"10"`
+	LoginLockoutDuration    time.Duration     `env:"LOGIN_LOCKOUT_DURATION" env-default:"15m"`
+	LoginIPLockoutAfter     int               `env:"LOGIN_IP_LOCKOUT_AFTER" env-default:"100"`
+	RateLimitStore          string            `env:"RATE_LIMIT_STORE" env-default:"redis"`
+	RateLimitDefault        string            `env:"RATE_LIMIT_DEFAULT" env-default:"600/1m"`
+	RateLimitMethods        map[string]string `env:"RATE_LIMIT_METHODS" env-default:"CreateUser:10/1h,LoginUser:30/1m,VerifyMFA:30/1m,RequestPasswordReset:5/1h"`
+	TracingExporter         string            `env:"TRACING_EXPORTER" env-default:"none"`
+	TracingEndpoint         string            `env:"TRACING_ENDPOINT" env-default:"localhost:4317"`
+	TracingInsecure         bool              `env:"TRACING_INSECURE"`
+	TracingSampleRatio      float64           `env:"TRACING_SAMPLE_RATIO" env-default:"1"`
 }
 
 func LoadConfig(path string) (*ConfigDatabase, error) {
@@ -0,0 +1,16 @@
+package util
+
+const (
+	DebitDirection  = "debit"
+	CreditDirection = "credit"
+)
+
+// IsSupportedDirection returns true if the entry direction is supported
+func IsSupportedDirection(direction string) bool {
+	switch direction {
+	case DebitDirection, CreditDirection:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package util
+
+const (
+	CSVFormat     = "csv"
+	OFXFormat     = "ofx"
+	CAMT053Format = "camt053"
+)
+
+// IsSupportedStatementFormat returns true if the statement export format is supported
+func IsSupportedStatementFormat(format string) bool {
+	switch format {
+	case CSVFormat, OFXFormat, CAMT053Format:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,15 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// DeriveKey derives a 32 character key for a specific purpose from the secret key,
+// so tokens signed for one purpose are never accepted for another
+func DeriveKey(secretKey string, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
@@ -0,0 +1,28 @@
+package util
+
+// Permissions are granted to roles in the role_permissions table
+const (
+	// CreateAccountPermission allows opening accounts for oneself
+	CreateAccountPermission = "accounts:create"
+	// ReadAnyAccountPermission allows reading accounts, entries and statements of any user
+	ReadAnyAccountPermission = "accounts:read:any"
+	// DeleteAnyAccountPermission allows deleting accounts of any user
+	DeleteAnyAccountPermission = "accounts:delete:any"
+	// CreateTransferPermission allows moving money out of one's own accounts
+	CreateTransferPermission = "transfers:create"
+	// ManageUsersPermission allows updating other users and revoking their sessions
+	ManageUsersPermission = "users:manage"
+	// ManageRolesPermission allows changing the permissions granted to roles
+	ManageRolesPermission = "roles:manage"
+)
+
+// IsSupportedPermission returns true if the permission is supported
+func IsSupportedPermission(permission string) bool {
+	switch permission {
+	case CreateAccountPermission, ReadAnyAccountPermission, DeleteAnyAccountPermission,
+		CreateTransferPermission, ManageUsersPermission, ManageRolesPermission:
+		return true
+	}
+
+	return false
+}
@@ -3,4 +3,16 @@ package util
 const (
 	DepositorRole = "depositor"
 	BankerRole    = "banker"
+	// SupportRole is for support staff, who can look at customer accounts but not move money
+	SupportRole = "support"
 )
+
+// IsSupportedRole returns true if the role is supported
+func IsSupportedRole(role string) bool {
+	switch role {
+	case DepositorRole, BankerRole, SupportRole:
+		return true
+	}
+
+	return false
+}
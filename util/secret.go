@@ -0,0 +1,21 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// secretCodeBytes is the entropy of a secret code, 256 bits
+const secretCodeBytes = 32
+
+// RandomSecretCode generates an unpredictable secret code for links sent by email,
+// 32 bytes from crypto/rand encoded as base64url
+func RandomSecretCode() (string, error) {
+	secret := make([]byte, secretCodeBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
@@ -2,13 +2,19 @@ package validate
 
 import (
 	"fmt"
+	"main/util"
 	"net/mail"
 	"regexp"
+	"time"
 )
 
+// MaxStatementPeriod is the longest period a statement may cover
+const MaxStatementPeriod = 366 * 24 * time.Hour
+
 var (
 	isValidUsername  = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
 	isValidFullrname = regexp.MustCompile(`^[a-zA-Z0-9\s]+$`).MatchString
+	isValidTOTPCode  = regexp.MustCompile(`^[0-9]{6}$`).MatchString
 )
 
 func ValidateString(value string, minLength int, maxLength int) error {
@@ -67,3 +73,88 @@ func ValidateEmailId(value int64) error {
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency")
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 1, 255)
+}
+
+func ValidateDirection(value string) error {
+	if !util.IsSupportedDirection(value) {
+		return fmt.Errorf("must be either %s or %s", util.DebitDirection, util.CreditDirection)
+	}
+	return nil
+}
+
+func ValidateStatementFormat(value string) error {
+	if !util.IsSupportedStatementFormat(value) {
+		return fmt.Errorf("must be one of %s, %s or %s", util.CSVFormat, util.OFXFormat, util.CAMT053Format)
+	}
+	return nil
+}
+
+func ValidateStatementPeriod(fromTime, toTime time.Time) error {
+	if !fromTime.Before(toTime) {
+		return fmt.Errorf("must be after from_time")
+	}
+	if toTime.Sub(fromTime) > MaxStatementPeriod {
+		return fmt.Errorf("must be at most %d days after from_time", int(MaxStatementPeriod.Hours()/24))
+	}
+	return nil
+}
+
+func ValidateTOTPCode(value string) error {
+	if !isValidTOTPCode(value) {
+		return fmt.Errorf("must contain exactly 6 digits")
+	}
+	return nil
+}
+
+func ValidateRecoveryCode(value string) error {
+	return ValidateString(value, 10, 16)
+}
+
+func ValidateRole(value string) error {
+	if !util.IsSupportedRole(value) {
+		return fmt.Errorf("must be one of %s, %s or %s", util.DepositorRole, util.BankerRole, util.SupportRole)
+	}
+	return nil
+}
+
+func ValidateSearch(value string) error {
+	return ValidateString(value, 1, 100)
+}
+
+func ValidatePermission(value string) error {
+	if !util.IsSupportedPermission(value) {
+		return fmt.Errorf("unsupported permission")
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 
 type TaskDistributor interface {
 	DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error
+	DistributeTaskSendMonthlyStatement(ctx context.Context, payload *PayloadSendMonthlyStatement, opts ...asynq.Option) error
+	DistributeTaskSendPasswordReset(ctx context.Context, payload *PayloadSendPasswordReset, opts ...asynq.Option) error
 }
 
 type RedisTaskDistributor struct {
@@ -10,17 +10,10 @@ type Logger struct {
 	log *slog.Logger
 }
 
+// NewLogger returns a Logger writing to the default slog logger
 func NewLogger() *Logger {
-	var logger *slog.Logger
-
-	if os.Getenv("ENVIRONMENT") == "dev" {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
-	} else {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	}
-
 	return &Logger{
-		log: logger,
+		log: slog.Default(),
 	}
 }
 
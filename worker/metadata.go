@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"main/logging"
+	"main/tracing"
+
+	"github.com/hibiken/asynq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("main/worker")
+
+// TaskMetadata carries the context of the call that enqueued a task to its handler.
+// It is embedded in every task payload
+type TaskMetadata struct {
+	// TraceContext holds the trace headers of the span that enqueued the task
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+	// RequestID is the id of the request that enqueued the task
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// startEnqueueSpan starts the span of enqueuing a task and returns the metadata to send with it
+func startEnqueueSpan(ctx context.Context, taskType string) (context.Context, trace.Span, TaskMetadata) {
+	ctx, span := tracer.Start(ctx, "enqueue "+taskType, trace.WithSpanKind(trace.SpanKindProducer))
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	return ctx, span, TaskMetadata{TraceContext: carrier, RequestID: logging.RequestID(ctx)}
+}
+
+// contextWithTaskMetadata restores the trace and request id of the call that enqueued the task
+func contextWithTaskMetadata(ctx context.Context, task *asynq.Task) context.Context {
+	// tasks without metadata, like scheduled ones, start a new trace and request id
+	var metadata TaskMetadata
+	_ = json.Unmarshal(task.Payload(), &metadata)
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(metadata.TraceContext))
+
+	return logging.NewContext(ctx, logging.RequestIDOrNew(metadata.RequestID))
+}
+
+// traceTask continues the trace and request id of the call that enqueued the task while processing it
+func traceTask(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		ctx = contextWithTaskMetadata(ctx, task)
+		ctx, span := tracer.Start(ctx, "process "+task.Type(), trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		err := next.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	})
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"main/logging"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceTaskContinuesEnqueuingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx, requestSpan := tracer.Start(logging.NewContext(context.Background(), "abc-123"), "CreateUser")
+	_, enqueueSpan, metadata := startEnqueueSpan(ctx, TaskSendVerifyEmail)
+	enqueueSpan.End()
+	requestSpan.End()
+
+	payload, err := json.Marshal(&PayloadSendVerifyEmail{TaskMetadata: metadata, Username: "alice"})
+	require.NoError(t, err)
+
+	var processSpan trace.SpanContext
+	var requestID string
+	handler := traceTask(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		processSpan = trace.SpanContextFromContext(ctx)
+		requestID = logging.RequestID(ctx)
+		return nil
+	}))
+
+	err = handler.ProcessTask(context.Background(), asynq.NewTask(TaskSendVerifyEmail, payload))
+	require.NoError(t, err)
+
+	require.Equal(t, requestSpan.SpanContext().TraceID(), processSpan.TraceID())
+	require.Equal(t, "abc-123", requestID)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "process "+TaskSendVerifyEmail, spans[2].Name())
+	require.Equal(t, enqueueSpan.SpanContext().SpanID(), spans[2].Parent().SpanID())
+}
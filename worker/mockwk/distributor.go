@@ -40,6 +40,44 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskSendMonthlyStatement mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendMonthlyStatement(arg0 context.Context, arg1 *worker.PayloadSendMonthlyStatement, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendMonthlyStatement", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendMonthlyStatement indicates an expected call of DistributeTaskSendMonthlyStatement.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendMonthlyStatement(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendMonthlyStatement", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendMonthlyStatement), varargs...)
+}
+
+// DistributeTaskSendPasswordReset mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPasswordReset(arg0 context.Context, arg1 *worker.PayloadSendPasswordReset, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPasswordReset", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPasswordReset indicates an expected call of DistributeTaskSendPasswordReset.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPasswordReset(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPasswordReset", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPasswordReset), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(arg0 context.Context, arg1 *worker.PayloadSendVerifyEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -2,10 +2,14 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"main/database/db"
+	"main/logging"
 	"main/mail"
-	"os"
+	"main/util"
+	"sync"
 
 	"github.com/hibiken/asynq"
 )
@@ -19,16 +23,35 @@ type TaskProcessor interface {
 	Start() error
 	Shutdown()
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskScheduleMonthlyStatements(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendMonthlyStatement(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPasswordReset(ctx context.Context, task *asynq.Task) error
+	// Check returns an error unless the processor is running and can reach Redis
+	Check() error
 }
 
 type RedisTaskProcessor struct {
-	server *asynq.Server
-	store  db.Store
-	mailer mail.EmailSender
+	server      *asynq.Server
+	store       db.Store
+	mailer      mail.EmailSender
+	distributor TaskDistributor
+	config      *util.ConfigDatabase
+
+	mu      sync.Mutex
+	running bool
+	// healthErr is the result of the latest Redis health check of the asynq server
+	healthErr error
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
-	server := asynq.NewServer(
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender, distributor TaskDistributor, config *util.ConfigDatabase) TaskProcessor {
+	processor := &RedisTaskProcessor{
+		store:       store,
+		mailer:      mailer,
+		distributor: distributor,
+		config:      config,
+	}
+
+	processor.server = asynq.NewServer(
 		redisOpt,
 		asynq.Config{
 			Queues: map[string]int{
@@ -38,37 +61,64 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
 				slogAttrs := []slog.Attr{
 					slog.String("type", task.Type()),
-					slog.String("payload", string(task.Payload())),
-				}
-
-				var logger *slog.Logger
-
-				if os.Getenv("ENVIRONMENT") == "dev" {
-					logger = slog.New(slog.NewTextHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-				} else {
-					logger = slog.New(slog.NewJSONHandler(os.Stdout, nil).WithAttrs(slogAttrs))
+					slog.String("payload", logging.RedactJSON(task.Payload())),
+					slog.String("error", err.Error()),
 				}
 
-				logger.Error("process task failed", slog.String("error", err.Error()))
+				slog.LogAttrs(contextWithTaskMetadata(ctx, task), slog.LevelError, "process task failed", slogAttrs...)
 			}),
 			Logger: NewLogger(),
+			HealthCheckFunc: func(err error) {
+				processor.mu.Lock()
+				defer processor.mu.Unlock()
+
+				processor.healthErr = err
+			},
 		},
 	)
 
-	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
-	}
+	return processor
 }
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(traceTask)
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskScheduleMonthlyStatements, processor.ProcessTaskScheduleMonthlyStatements)
+	mux.HandleFunc(TaskSendMonthlyStatement, processor.ProcessTaskSendMonthlyStatement)
+	mux.HandleFunc(TaskSendPasswordReset, processor.ProcessTaskSendPasswordReset)
+
+	err := processor.server.Start(mux)
+	if err != nil {
+		return err
+	}
+
+	processor.mu.Lock()
+	defer processor.mu.Unlock()
 
-	return processor.server.Start(mux)
+	processor.running = true
+	return nil
 }
 
 func (processor *RedisTaskProcessor) Shutdown() {
+	processor.mu.Lock()
+	processor.running = false
+	processor.mu.Unlock()
+
 	processor.server.Shutdown()
 }
+
+func (processor *RedisTaskProcessor) Check() error {
+	processor.mu.Lock()
+	defer processor.mu.Unlock()
+
+	if !processor.running {
+		return errors.New("task processor is not running")
+	}
+
+	if processor.healthErr != nil {
+		return fmt.Errorf("task processor cannot reach redis: %w", processor.healthErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// MonthlyStatementCronSpec runs at midnight UTC on the 1st of each month
+const MonthlyStatementCronSpec = "0 0 1 * *"
+
+type TaskScheduler interface {
+	Start() error
+	Shutdown()
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt) TaskScheduler {
+	scheduler := asynq.NewScheduler(
+		redisOpt,
+		&asynq.SchedulerOpts{
+			Location: time.UTC,
+			Logger:   NewLogger(),
+		},
+	)
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+	}
+}
+
+func (scheduler *RedisTaskScheduler) Start() error {
+	// fans out into one TaskSendMonthlyStatement per account
+	task := asynq.NewTask(TaskScheduleMonthlyStatements, nil)
+	_, err := scheduler.scheduler.Register(MonthlyStatementCronSpec, task, asynq.Queue(QueueDefault))
+	if err != nil {
+		return err
+	}
+
+	return scheduler.scheduler.Start()
+}
+
+func (scheduler *RedisTaskScheduler) Shutdown() {
+	scheduler.scheduler.Shutdown()
+}
@@ -0,0 +1,220 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"main/database/db"
+	"main/logging"
+	"main/statement"
+	"main/util"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	TaskScheduleMonthlyStatements = "task:schedule_monthly_statements"
+	TaskSendMonthlyStatement      = "task:send_monthly_statement"
+)
+
+// statementAccountsBatchSize is the number of accounts loaded at once when fanning out monthly statements
+const statementAccountsBatchSize = 100
+
+// statementTaskRetention keeps finished statement tasks, and so their task ids, for longer
+// than a month, so a fan-out run later by another replica doesn't send them again
+const statementTaskRetention = 32 * 24 * time.Hour
+
+type PayloadSendMonthlyStatement struct {
+	TaskMetadata
+	AccountID int64     `json:"account_id"`
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendMonthlyStatement(ctx context.Context, payload *PayloadSendMonthlyStatement, opts ...asynq.Option) error {
+	ctx, span, metadata := startEnqueueSpan(ctx, TaskSendMonthlyStatement)
+	defer span.End()
+	payload.TaskMetadata = metadata
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendMonthlyStatement, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	slogAttrs := []slog.Attr{
+		slog.String("type", task.Type()),
+		slog.String("payload", logging.RedactJSON(task.Payload())),
+		slog.String("queue", info.Queue),
+		slog.Int("max_retry", info.MaxRetry),
+	}
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "enqueued task", slogAttrs...)
+
+	return nil
+}
+
+// previousMonth returns the first instant of the previous month and of the current month in UTC
+func previousMonth(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	toTime := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	fromTime := toTime.AddDate(0, -1, 0)
+
+	return fromTime, toTime
+}
+
+// ProcessTaskScheduleMonthlyStatements enqueues one statement task per account whose owner hasn't opted out
+func (processor *RedisTaskProcessor) ProcessTaskScheduleMonthlyStatements(ctx context.Context, task *asynq.Task) error {
+	fromTime, toTime := previousMonth(time.Now())
+
+	count := 0
+	cursorID := int64(0)
+	for {
+		accounts, err := processor.store.ListStatementAccounts(ctx, &db.ListStatementAccountsParams{
+			CursorID:  cursorID,
+			PageLimit: statementAccountsBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list statement accounts: %w", err)
+		}
+
+		for _, account := range accounts {
+			payload := &PayloadSendMonthlyStatement{
+				AccountID: account.ID,
+				FromTime:  fromTime,
+				ToTime:    toTime,
+			}
+
+			// the task id makes retries and the schedulers of every replica enqueue each statement
+			// only once, for as long as the finished task is retained
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.Queue(QueueDefault),
+				asynq.TaskID(fmt.Sprintf("monthly_statement:%d:%s", account.ID, fromTime.Format("2006-01"))),
+				asynq.Retention(statementTaskRetention),
+			}
+
+			err = processor.distributor.DistributeTaskSendMonthlyStatement(ctx, payload, opts...)
+			if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+				return fmt.Errorf("failed to distribute monthly statement task: %w", err)
+			}
+		}
+
+		count += len(accounts)
+		if len(accounts) < statementAccountsBatchSize {
+			break
+		}
+		cursorID = accounts[len(accounts)-1].ID
+	}
+
+	slogAttrs := []slog.Attr{
+		slog.String("type", task.Type()),
+		slog.Int("accounts", count),
+		slog.Time("from_time", fromTime),
+		slog.Time("to_time", toTime),
+	}
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "processed task", slogAttrs...)
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendMonthlyStatement(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendMonthlyStatement
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	account, err := processor.store.GetAccount(ctx, payload.AccountID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("account doesn't exist: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// the user may have opted out after the task was scheduled
+	if user.MonthlyStatementOptOut {
+		return nil
+	}
+
+	txResult, err := processor.store.StatementTx(ctx, &db.StatementTxParams{
+		AccountID: account.ID,
+		FromTime:  payload.FromTime,
+		ToTime:    payload.ToTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read statement: %w", err)
+	}
+	balances, entries := txResult.Balances, txResult.Entries
+
+	transfers := []*db.Transfer{}
+	if transferIDs := statement.TransferIDs(entries); len(transferIDs) > 0 {
+		transfers, err = processor.store.ListTransfersByIDs(ctx, transferIDs)
+		if err != nil {
+			return fmt.Errorf("failed to list statement transfers: %w", err)
+		}
+	}
+
+	stmt := statement.New(account, payload.FromTime, payload.ToTime, balances, entries, transfers)
+
+	var content bytes.Buffer
+	if err := stmt.RenderSummary(&content, user.FullName); err != nil {
+		return fmt.Errorf("failed to render statement summary: %w", err)
+	}
+
+	// the email sender attaches files from disk
+	dir, err := os.MkdirTemp("", "statement")
+	if err != nil {
+		return fmt.Errorf("failed to create statement directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	attachment := filepath.Join(dir, stmt.FileName(util.CSVFormat))
+	file, err := os.Create(attachment)
+	if err != nil {
+		return fmt.Errorf("failed to create statement file: %w", err)
+	}
+
+	err = stmt.Render(file, util.CSVFormat)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to render statement: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your Simple Bank statement for %s", payload.FromTime.Format("January 2006"))
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content.String(), to, nil, nil, []string{attachment})
+	if err != nil {
+		return fmt.Errorf("failed to send monthly statement email: %w", err)
+	}
+
+	slogAttrs := []slog.Attr{
+		slog.String("type", task.Type()),
+		slog.String("payload", logging.RedactJSON(task.Payload())),
+		slog.String("username", user.Username),
+	}
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "processed task", slogAttrs...)
+	return nil
+}
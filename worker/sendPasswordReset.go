@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"main/database/db"
+	"main/logging"
+	"main/util"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hibiken/asynq"
+)
+
+const TaskSendPasswordReset = "task:send_password_reset"
+
+var passwordResetTemplate = template.Must(template.New("password_reset").Parse(`
+	<h1>Hello {{.FullName}}</h1>
+	<p>We received a request to reset the password of your account.</p>
+	<p>Please <a href="{{.ResetURL}}">click here</a> to choose a new password. The link expires in 15 minutes.</p>
+	<p>If you did not request a password reset you can ignore this email.</p>
+	`))
+
+type passwordResetData struct {
+	FullName string
+	ResetURL string
+}
+
+type PayloadSendPasswordReset struct {
+	TaskMetadata
+	Username string `json:"username"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPasswordReset(ctx context.Context, payload *PayloadSendPasswordReset, opts ...asynq.Option) error {
+	ctx, span, metadata := startEnqueueSpan(ctx, TaskSendPasswordReset)
+	defer span.End()
+	payload.TaskMetadata = metadata
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPasswordReset, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	slogAttrs := []slog.Attr{
+		slog.String("type", task.Type()),
+		slog.String("payload", logging.RedactJSON(task.Payload())),
+		slog.String("queue", info.Queue),
+		slog.Int("max_retry", info.MaxRetry),
+	}
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "enqueued task", slogAttrs...)
+
+	return nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordReset(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPasswordReset
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secretCode, err := util.RandomSecretCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate secret code: %w", err)
+	}
+
+	passwordReset, err := processor.store.CreatePasswordReset(ctx, &db.CreatePasswordResetParams{
+		Username:   user.Username,
+		SecretCode: secretCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	subject := "Reset your Simple Bank password"
+	var content bytes.Buffer
+	err = passwordResetTemplate.Execute(&content, passwordResetData{
+		FullName: user.FullName,
+		ResetURL: passwordResetURL(processor.config.AppBaseURL, passwordReset),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render password reset email: %w", err)
+	}
+	to := []string{user.Email}
+
+	err = processor.mailer.SendEmail(subject, content.String(), to, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	slogAttrs := []slog.Attr{
+		slog.String("type", task.Type()),
+		slog.String("payload", logging.RedactJSON(task.Payload())),
+		slog.String("username", user.Username),
+	}
+
+	slog.LogAttrs(ctx, slog.LevelInfo, "processed task", slogAttrs...)
+	return nil
+}
+
+// passwordResetURL links to the password reset page. The reset id and secret code are
+// in the fragment, so they never reach server logs or the Referer header of other sites
+func passwordResetURL(baseURL string, passwordReset *db.PasswordReset) string {
+	fragment := url.Values{}
+	fragment.Set("reset_id", strconv.FormatInt(passwordReset.ID, 10))
+	fragment.Set("secret_code", passwordReset.SecretCode)
+
+	return strings.TrimSuffix(baseURL, "/") + "/reset_password#" + fragment.Encode()
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"bytes"
+	"main/database/db"
+	"main/util"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordResetURL(t *testing.T) {
+	secretCode, err := util.RandomSecretCode()
+	require.NoError(t, err)
+	require.Len(t, secretCode, 43)
+
+	resetURL := passwordResetURL("https://bank.example.com/", &db.PasswordReset{ID: 7, SecretCode: secretCode})
+
+	parsed, err := url.Parse(resetURL)
+	require.NoError(t, err)
+	require.Equal(t, "bank.example.com", parsed.Host)
+	require.Equal(t, "/reset_password", parsed.Path)
+	require.Empty(t, parsed.RawQuery)
+
+	fragment, err := url.ParseQuery(parsed.Fragment)
+	require.NoError(t, err)
+	require.Equal(t, "7", fragment.Get("reset_id"))
+	require.Equal(t, secretCode, fragment.Get("secret_code"))
+}
+
+func TestPasswordResetTemplateEscapesFullName(t *testing.T) {
+	secretCode, err := util.RandomSecretCode()
+	require.NoError(t, err)
+
+	var content bytes.Buffer
+	err = passwordResetTemplate.Execute(&content, passwordResetData{
+		FullName: `<a href="https://evil.example.com">Eve</a>`,
+		ResetURL: passwordResetURL("https://bank.example.com", &db.PasswordReset{ID: 7, SecretCode: secretCode}),
+	})
+	require.NoError(t, err)
+
+	body := content.String()
+	require.NotContains(t, body, "evil.example.com\">")
+	require.Contains(t, body, "&lt;a href=&#34;https://evil.example.com&#34;&gt;Eve&lt;/a&gt;")
+	require.Equal(t, 1, strings.Count(body, "<a "))
+	require.Contains(t, body, `href="https://bank.example.com/reset_password#reset_id=7&amp;secret_code=`+secretCode+`"`)
+}
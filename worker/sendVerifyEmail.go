@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"main/database/db"
+	"main/logging"
 	"main/util"
-	"os"
 
 	"github.com/hibiken/asynq"
 )
@@ -15,10 +15,15 @@ import (
 const TaskSendVerifyEmail = "task:send_verify_email"
 
 type PayloadSendVerifyEmail struct {
+	TaskMetadata
 	Username string `json:"username"`
 }
 
 func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(ctx context.Context, payload *PayloadSendVerifyEmail, opts ...asynq.Option) error {
+	ctx, span, metadata := startEnqueueSpan(ctx, TaskSendVerifyEmail)
+	defer span.End()
+	payload.TaskMetadata = metadata
+
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal task payload: %w", err)
@@ -32,20 +37,12 @@ func (distributor *RedisTaskDistributor) DistributeTaskSendVerifyEmail(ctx conte
 
 	slogAttrs := []slog.Attr{
 		slog.String("type", task.Type()),
-		slog.String("payload", string(task.Payload())),
+		slog.String("payload", logging.RedactJSON(task.Payload())),
 		slog.String("queue", info.Queue),
 		slog.Int("max_retry", info.MaxRetry),
 	}
 
-	var logger *slog.Logger
-
-	if os.Getenv("ENVIRONMENT") == "dev" {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-	} else {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-	}
-
-	logger.Info("enqueued task")
+	slog.LogAttrs(ctx, slog.LevelInfo, "enqueued task", slogAttrs...)
 
 	return nil
 }
@@ -90,18 +87,10 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Cont
 
 	slogAttrs := []slog.Attr{
 		slog.String("type", task.Type()),
-		slog.String("payload", string(task.Payload())),
+		slog.String("payload", logging.RedactJSON(task.Payload())),
 		slog.String("email", user.Email),
 	}
 
-	var logger *slog.Logger
-
-	if os.Getenv("ENVIRONMENT") == "dev" {
-		logger = slog.New(slog.NewTextHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-	} else {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil).WithAttrs(slogAttrs))
-	}
-
-	logger.Info("processed task")
+	slog.LogAttrs(ctx, slog.LevelInfo, "processed task", slogAttrs...)
 	return nil
 }